@@ -0,0 +1,89 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// albumTracksURL is an URL of album's tracks endpoint.
+const albumTracksURL = endPointURL + "albums/%s/tracks"
+
+// albumTracksLimit is a maximum page size of album's tracks endpoint.
+const albumTracksLimit = 50
+
+// AlbumTracks returns Pager over tracks of album identified by uri. Tracks
+// have AlbumURI set, but no AlbumName, popularity nor ISRC, as Web API
+// returns simplified tracks.
+func (c *Client) AlbumTracks(ctx context.Context, uri URI,
+	o PageOpts) (*Pager[Track], error) {
+	id, err := lookupID(KindAlbum, uri)
+	if err != nil {
+		return nil, err
+	}
+	alb := uriScheme + ":" + KindAlbum + ":" + id
+	return newPager(ctx, c.get, fmt.Sprintf(albumTracksURL, id), o,
+		func(_ context.Context, b []byte) ([]Track, respHeader, error) {
+			var resp trackPage
+			if err := unmarshal(b, &resp); err != nil {
+				return nil, respHeader{}, err
+			}
+			var res []Track
+			for _, t := range resp.Items {
+				if t != nil {
+					tr := t.conv()
+					tr.AlbumURI = alb
+					res = append(res, tr)
+				}
+			}
+			return res, resp.respHeader, nil
+		}), nil
+}
+
+// Tracklist is a complete list of tracks of an album.
+type Tracklist struct {
+	Album    Album         // Album is the album the tracks belong to.
+	Tracks   []Track       // Tracks ordered by disc and track number.
+	Duration time.Duration // Duration is a total runtime of the album.
+}
+
+// Discs returns a number of discs of the album.
+func (t *Tracklist) Discs() int {
+	n := 0
+	for i := range t.Tracks {
+		if t.Tracks[i].DiscNumber > n {
+			n = t.Tracks[i].DiscNumber
+		}
+	}
+	return n
+}
+
+// Tracklist returns album identified by uri with all its tracks.
+func (c *Client) Tracklist(ctx context.Context, uri URI) (*Tracklist,
+	error) {
+	a, err := c.GetAlbum(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	p, err := c.AlbumTracks(ctx, uri, PageOpts{Limit: albumTracksLimit})
+	if err != nil {
+		return nil, err
+	}
+	tr, err := p.All()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tr, func(i, j int) bool {
+		if tr[i].DiscNumber != tr[j].DiscNumber {
+			return tr[i].DiscNumber < tr[j].DiscNumber
+		}
+		return tr[i].TrackNumber < tr[j].TrackNumber
+	})
+	res := &Tracklist{Album: a, Tracks: tr}
+	for i := range tr {
+		tr[i].AlbumName = a.Name
+		res.Duration += tr[i].Duration
+	}
+	return res, nil
+}
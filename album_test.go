@@ -0,0 +1,61 @@
+package spotify
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTracklist(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{
+		jsonData(t, "album.json"),
+		jsonData(t, "album_tracks_1.json"),
+		jsonData(t, "album_tracks_2.json"),
+	}}
+	c := &Client{get: g}
+	tl, err := c.Tracklist(context.Background(),
+		"spotify:album:4LJbsUCNTcNNNHNiX6qES1")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if tl.Album.Name != "POD" {
+		t.Errorf("want album=%q; got %q", "POD", tl.Album.Name)
+	}
+	want := []struct {
+		uri         string
+		disc, track int
+	}{
+		{"spotify:track:6crBy2sODw2HS53xquM6us", 1, 3},
+		{"spotify:track:3USOTQdPtZlgVurwaqsdI5", 1, 6},
+		{"spotify:track:7jxSwduTONaRn00SQQbInK", 2, 1},
+	}
+	if len(tl.Tracks) != len(want) {
+		t.Fatalf("want %d tracks; got %d", len(want), len(tl.Tracks))
+	}
+	for i, w := range want {
+		tr := tl.Tracks[i]
+		if tr.URI != w.uri || tr.DiscNumber != w.disc ||
+			tr.TrackNumber != w.track {
+			t.Errorf("want %v; got %v (%d)", w, tr, i)
+		}
+		if tr.AlbumURI != "spotify:album:4LJbsUCNTcNNNHNiX6qES1" ||
+			tr.AlbumName != "POD" {
+			t.Errorf("invalid album of track: %q, %q (%d)", tr.AlbumURI,
+				tr.AlbumName, i)
+		}
+	}
+	if !tl.Tracks[0].Explicit || tl.Tracks[2].Explicit {
+		t.Errorf("invalid explicit flags: %v", tl.Tracks)
+	}
+	if d := (248053 + 123666 + 169567) * time.Millisecond; tl.Duration != d {
+		t.Errorf("want duration=%v; got %v", d, tl.Duration)
+	}
+	if n := tl.Discs(); n != 2 {
+		t.Errorf("want discs=2; got %d", n)
+	}
+	u := endPointURL + "albums/4LJbsUCNTcNNNHNiX6qES1/tracks?limit=50"
+	if g.u[1] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[1])
+	}
+}
@@ -0,0 +1,114 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Groups of artist's albums, which can be requested with ArtistAlbums.
+const (
+	GroupAlbum       = "album"
+	GroupSingle      = "single"
+	GroupAppearsOn   = "appears_on"
+	GroupCompilation = "compilation"
+)
+
+// strings used for requesting artist's sub-resources
+const (
+	artistAlbumsURL   = endPointURL + "artists/%s/albums"
+	topTracksURL      = endPointURL + "artists/%s/top-tracks?market=%s"
+	relatedArtistsURL = endPointURL + "artists/%s/related-artists"
+)
+
+// ArtistAlbums returns Pager over albums of artist identified by uri.
+// Albums are restricted to groups, all groups are returned if none is
+// provided. If market is not empty, only albums available in the market are
+// returned.
+func (c *Client) ArtistAlbums(ctx context.Context, uri URI, groups []string,
+	market string, o PageOpts) (*Pager[Album], error) {
+	id, err := lookupID(KindArtist, uri)
+	if err != nil {
+		return nil, err
+	}
+	v := url.Values{}
+	for _, g := range groups {
+		switch g {
+		case GroupAlbum, GroupSingle, GroupAppearsOn, GroupCompilation:
+		default:
+			return nil, errorf("invalid album group: %q", g)
+		}
+	}
+	if len(groups) > 0 {
+		v.Set("include_groups", strings.Join(groups, ","))
+	}
+	if market != "" {
+		v.Set("market", market)
+	}
+	u := fmt.Sprintf(artistAlbumsURL, id)
+	if len(v) > 0 {
+		u += "?" + v.Encode()
+	}
+	return newPager(ctx, c.get, u, o, decodeAlbumPage), nil
+}
+
+// decodeAlbumPage decodes page of simplified albums with their artists.
+func decodeAlbumPage(_ context.Context, b []byte) ([]Album, respHeader,
+	error) {
+	var resp albumPage
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
+	}
+	var res []Album
+	for _, a := range resp.Items {
+		if a != nil {
+			res = append(res, a.conv())
+		}
+	}
+	return res, resp.respHeader, nil
+}
+
+// TopTracks returns the most popular tracks of artist identified by uri
+// in market, which is ISO 3166-1 alpha-2 country code.
+func (c *Client) TopTracks(ctx context.Context, uri URI,
+	market string) ([]Track, error) {
+	id, err := lookupID(KindArtist, uri)
+	if err != nil {
+		return nil, err
+	}
+	if market == "" {
+		return nil, errorf("top tracks: market is required")
+	}
+	var resp struct {
+		Tracks []*trackData `json:"tracks"`
+	}
+	if err = c.getJSON(ctx, fmt.Sprintf(topTracksURL, id,
+		url.QueryEscape(market)), &resp); err != nil {
+		return nil, err
+	}
+	var res []Track
+	for _, t := range resp.Tracks {
+		if t != nil {
+			res = append(res, t.conv())
+		}
+	}
+	return res, nil
+}
+
+// RelatedArtists returns artists similar to artist identified by uri.
+func (c *Client) RelatedArtists(ctx context.Context, uri URI) ([]Artist,
+	error) {
+	id, err := lookupID(KindArtist, uri)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Artists artists `json:"artists"`
+	}
+	if err = c.getJSON(ctx, fmt.Sprintf(relatedArtistsURL, id),
+		&resp); err != nil {
+		return nil, err
+	}
+	return resp.Artists.conv(), nil
+}
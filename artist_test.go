@@ -0,0 +1,93 @@
+package spotify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestArtistAlbums(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{
+		jsonData(t, "artist_albums_1.json"),
+		jsonData(t, "artist_albums_2.json"),
+	}}
+	c := &Client{get: g}
+	p, err := c.ArtistAlbums(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh",
+		[]string{GroupAlbum, GroupAppearsOn}, "PL", PageOpts{Limit: 2})
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	res, err := p.All()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if len(res) != 3 || p.Total() != 3 {
+		t.Fatalf("want 3 albums; got %d (total %d)", len(res), p.Total())
+	}
+	if res[0].URI != "spotify:album:4LJbsUCNTcNNNHNiX6qES1" ||
+		res[0].Group != GroupAlbum || len(res[0].Artists) == 0 {
+		t.Errorf("invalid first album: %v", res[0])
+	}
+	if res[2].Group != GroupAppearsOn {
+		t.Errorf("want group=%q; got %q", GroupAppearsOn, res[2].Group)
+	}
+	u := endPointURL + "artists/1XpDYCrUJnvCo9Ez6yeMWh/albums?" +
+		"include_groups=album%2Cappears_on&market=PL&limit=2"
+	if g.u[0] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[0])
+	}
+}
+
+func TestArtistAlbumsInvalid(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{}}
+	if _, err := c.ArtistAlbums(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", []string{"live"}, "",
+		PageOpts{}); err == nil {
+		t.Error("want err!=nil for invalid group")
+	}
+	if _, err := c.ArtistAlbums(context.Background(),
+		"spotify:album:4LJbsUCNTcNNNHNiX6qES1", nil, "",
+		PageOpts{}); err == nil {
+		t.Error("want err!=nil for album URI")
+	}
+}
+
+func TestTopTracks(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "top_tracks.json")}}
+	c := &Client{get: g}
+	res, err := c.TopTracks(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "US")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !reflect.DeepEqual(res, searchTrackFixt.res[0]) {
+		t.Errorf("want %v; got %v", searchTrackFixt.res[0], res)
+	}
+	u := endPointURL + "artists/1XpDYCrUJnvCo9Ez6yeMWh/top-tracks?market=US"
+	if g.u[0] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[0])
+	}
+	if _, err = c.TopTracks(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", ""); err == nil {
+		t.Error("want err!=nil for empty market")
+	}
+}
+
+func TestRelatedArtists(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{
+		jsonData(t, "related_artists.json"),
+	}}}
+	res, err := c.RelatedArtists(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if want := searchArtistFixt.res[0][1:3]; !reflect.DeepEqual(res, want) {
+		t.Errorf("want %v; got %v", want, res)
+	}
+}
@@ -0,0 +1,179 @@
+package spotify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenURL is an URL of Spotify Accounts service token endpoint.
+const tokenURL = "https://accounts.spotify.com/api/token"
+
+// expiryDelta is a margin subtracted from token's lifetime, so the token
+// is not used right before it expires.
+const expiryDelta = 10 * time.Second
+
+// Token is a bearer token used to authorize requests to Spotify Web API.
+type Token struct {
+	AccessToken  string    `json:"access_token"`            // AccessToken is a value of the token.
+	TokenType    string    `json:"token_type"`              // TokenType is a type of the token, usually "Bearer".
+	RefreshToken string    `json:"refresh_token,omitempty"` // RefreshToken is used to obtain new access token.
+	Scope        string    `json:"scope,omitempty"`         // Scope is a list of granted scopes.
+	Expiry       time.Time `json:"expiry"`                  // Expiry is the time when the token expires.
+}
+
+// Valid returns a boolean indicating whether t is set and not expired.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" &&
+		time.Now().Add(expiryDelta).Before(t.Expiry)
+}
+
+// header returns a value of Authorization header for t.
+func (t *Token) header() string {
+	typ := t.TokenType
+	if typ == "" || strings.EqualFold(typ, "bearer") {
+		typ = "Bearer"
+	}
+	return typ + " " + t.AccessToken
+}
+
+// TokenSource is an interface for anything providing tokens authorizing
+// requests to Spotify Web API.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// invalidator is implemented by token sources caching tokens. Calling
+// invalidate forces obtaining a new token on next call to Token.
+type invalidator interface {
+	invalidate()
+}
+
+// ClientCredentials is a TokenSource obtaining tokens with client credentials
+// flow. Tokens are cached until they expire. Zero value of TokenURL means
+// default token endpoint.
+type ClientCredentials struct {
+	ID       string // ID is a client ID of registered application.
+	Secret   string // Secret is a client secret of registered application.
+	TokenURL string // TokenURL is an URL of token endpoint.
+
+	mu  sync.Mutex
+	tok *Token
+	c   *http.Client
+}
+
+// defaultHTTPClient is used for token requests by token sources created
+// without constructors.
+var defaultHTTPClient = &http.Client{Timeout: timeout}
+
+// NewClientCredentials returns ClientCredentials for application identified
+// by id and secret, which uses default token endpoint.
+func NewClientCredentials(id, secret string) *ClientCredentials {
+	return &ClientCredentials{
+		ID:       id,
+		Secret:   secret,
+		TokenURL: tokenURL,
+		c:        &http.Client{Timeout: timeout},
+	}
+}
+
+// Token implements TokenSource.
+func (cc *ClientCredentials) Token() (*Token, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if cc.tok.Valid() {
+		return cc.tok, nil
+	}
+	u := cc.TokenURL
+	if u == "" {
+		u = tokenURL
+	}
+	tok, err := requestToken(cc.c, u, url.Values{
+		"grant_type": {"client_credentials"},
+	}, cc.ID, cc.Secret)
+	if err != nil {
+		return nil, err
+	}
+	cc.tok = tok
+	return tok, nil
+}
+
+// invalidate implements invalidator.
+func (cc *ClientCredentials) invalidate() {
+	cc.mu.Lock()
+	cc.tok = nil
+	cc.mu.Unlock()
+}
+
+// tokenResp is a response of token endpoint.
+type tokenResp struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// authError is returned by token endpoint on failure.
+type authError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+	Status      int    `json:"-"`
+}
+
+// Error implements `error`.
+func (e authError) Error() string {
+	return fmt.Sprintf("[spotify]: auth failed: code: %d, error: %q, "+
+		"description: %q", e.Status, e.Code, e.Description)
+}
+
+// requestToken sends form v to token endpoint u and returns obtained token.
+// If id and secret are not empty, they are sent as basic auth credentials.
+// If c is nil, defaultHTTPClient is used.
+func requestToken(c *http.Client, u string, v url.Values, id,
+	secret string) (*Token, error) {
+	if c == nil {
+		c = defaultHTTPClient
+	}
+	req, err := http.NewRequest("POST", u, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if id != "" && secret != "" {
+		req.SetBasicAuth(id, secret)
+	}
+	r, err := c.Do(req)
+	if err != nil {
+		return nil, errorf("token request failed: %q", err)
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode != http.StatusOK {
+		e := authError{Status: r.StatusCode}
+		json.Unmarshal(body, &e)
+		return nil, e
+	}
+	var resp tokenResp
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.AccessToken == "" {
+		return nil, errorf("token endpoint returned no access token")
+	}
+	return &Token{
+		AccessToken:  resp.AccessToken,
+		TokenType:    resp.TokenType,
+		RefreshToken: resp.RefreshToken,
+		Scope:        resp.Scope,
+		Expiry:       time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second),
+	}, nil
+}
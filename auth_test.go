@@ -0,0 +1,140 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func tokenServer(t *testing.T, cnt *int32, expires int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			id, secret, ok := r.BasicAuth()
+			if !ok || id != "id" || secret != "secret" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"invalid_client",`+
+					`"error_description":"Invalid client"}`)
+				return
+			}
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("want err=nil; got %q", err)
+			}
+			if g := r.PostForm.Get("grant_type"); g != "client_credentials" {
+				t.Errorf("want g=client_credentials; got %q", g)
+			}
+			n := atomic.AddInt32(cnt, 1)
+			fmt.Fprintf(w, `{"access_token":"tok%d","token_type":"bearer",`+
+				`"expires_in":%d}`, n, expires)
+		}))
+}
+
+func TestClientCredentials(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		secret  string
+		expires int
+		calls   int
+		tok     string
+		isnil   bool
+	}{
+		{
+			secret:  "secret",
+			expires: 3600,
+			calls:   1,
+			tok:     "tok1",
+			isnil:   true,
+		},
+		{
+			secret:  "secret",
+			expires: 0,
+			calls:   3,
+			tok:     "tok3",
+			isnil:   true,
+		},
+		{
+			secret:  "invalid",
+			expires: 3600,
+			calls:   0,
+			isnil:   false,
+		},
+	}
+	for i, cas := range cases {
+		var cnt int32
+		srv := tokenServer(t, &cnt, cas.expires)
+		cc := NewClientCredentials("id", cas.secret)
+		cc.TokenURL = srv.URL
+		var tok *Token
+		var err error
+		for j := 0; j < 3; j++ {
+			if tok, err = cc.Token(); err != nil {
+				break
+			}
+		}
+		srv.Close()
+		if (err == nil) != cas.isnil {
+			t.Errorf("want (err=nil)=isnil; err: %v, isnil: %t (%d)",
+				err, cas.isnil, i)
+			continue
+		}
+		if n := int(atomic.LoadInt32(&cnt)); n != cas.calls {
+			t.Errorf("want n=cas.calls; got %d=%d (%d)", n, cas.calls, i)
+		}
+		if err == nil && tok.AccessToken != cas.tok {
+			t.Errorf("want tok=cas.tok; got %q=%q (%d)",
+				tok.AccessToken, cas.tok, i)
+		}
+	}
+}
+
+func TestClientCredentialsZeroValue(t *testing.T) {
+	t.Parallel()
+	var cnt int32
+	srv := tokenServer(t, &cnt, 3600)
+	defer srv.Close()
+	cc := &ClientCredentials{ID: "id", Secret: "secret", TokenURL: srv.URL}
+	tok, err := cc.Token()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if tok.AccessToken != "tok1" {
+		t.Errorf("want tok=tok1; got %q", tok.AccessToken)
+	}
+}
+
+func TestGetRefreshesToken(t *testing.T) {
+	t.Parallel()
+	var cnt int32
+	ts := tokenServer(t, &cnt, 3600)
+	defer ts.Close()
+	api := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer tok2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{"error":{"status":401,`+
+					`"message":"The access token expired"}}`)
+				return
+			}
+			fmt.Fprint(w, "ok")
+		}))
+	defer api.Close()
+	cc := NewClientCredentials("id", "secret")
+	cc.TokenURL = ts.URL
+	r, err := newGet(cc).get(context.Background(), api.URL)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		t.Errorf("want r.StatusCode=200; got %d", r.StatusCode)
+	}
+	if b, _ := ioutil.ReadAll(r.Body); string(b) != "ok" {
+		t.Errorf("want b=ok; got %q", b)
+	}
+	if n := atomic.LoadInt32(&cnt); n != 2 {
+		t.Errorf("want n=2; got %d", n)
+	}
+}
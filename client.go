@@ -0,0 +1,216 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client is an authorized client of Spotify Web API. A single Client can be
+// shared by Search and APIs operating on user's data.
+type Client struct {
+	get geter // get is used for http GET requests.
+}
+
+// NewClient returns Client authorizing requests with tokens obtained from ts.
+// Client credentials (NewClientCredentials) are sufficient for accessing
+// catalog data, while user's data requires token obtained with Login.
+func NewClient(ts TokenSource) *Client {
+	return &Client{get: newGet(ts)}
+}
+
+// SetRetryPolicy sets policy of retrying failed requests sent by c.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	if g, ok := c.get.(*get); ok {
+		g.policy = p
+	}
+}
+
+// SetEndpoint sets base URL of Web API used by c instead of the default
+// https://api.spotify.com/v1/, e.g. to use a local fake server in tests.
+func (c *Client) SetEndpoint(u string) {
+	if g, ok := c.get.(*get); ok {
+		g.base = strings.TrimSuffix(u, "/") + "/"
+	}
+}
+
+// RetryStats returns statistics of requests sent by c.
+func (c *Client) RetryStats() RetryStats {
+	if g, ok := c.get.(*get); ok {
+		return g.stats.snapshot()
+	}
+	return RetryStats{}
+}
+
+// Search returns Search instance sending requests through c.
+func (c *Client) Search() *Search {
+	return &Search{
+		get:   c.get,
+		batch: 50,
+	}
+}
+
+// Me returns profile of the user who authorized c.
+func (c *Client) Me(ctx context.Context) (User, error) {
+	var resp userResp
+	if err := c.getJSON(ctx, endPointURL+"me", &resp); err != nil {
+		return User{}, err
+	}
+	return User{
+		ID:      resp.ID,
+		URI:     resp.URI,
+		Name:    resp.DisplayName,
+		Email:   resp.Email,
+		Country: resp.Country,
+		Product: resp.Product,
+	}, nil
+}
+
+// sendJSON sends request with method to url. If req is not nil, it is sent
+// JSON encoded in the request body. If resp is not nil, decoded response is
+// stored in it.
+func (c *Client) sendJSON(ctx context.Context, method, url string, req,
+	resp interface{}) error {
+	s, ok := c.get.(sender)
+	if !ok {
+		return errorf("%s requests are not supported", method)
+	}
+	var b []byte
+	if req != nil {
+		var err error
+		if b, err = json.Marshal(req); err != nil {
+			return err
+		}
+	}
+	r, err := s.send(ctx, method, url, b)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	if r.StatusCode >= 400 {
+		var e webError
+		if json.Unmarshal(body, &e) == nil && e.Err.Status != 0 {
+			return e
+		}
+		return errorf("%s %s failed: %s", method, url, r.Status)
+	}
+	if resp == nil || len(body) == 0 {
+		return nil
+	}
+	return unmarshal(body, resp)
+}
+
+// getJSON sends GET request to url and stores decoded response in resp.
+func (c *Client) getJSON(ctx context.Context, url string,
+	resp interface{}) error {
+	r, err := c.get.get(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return unmarshal(body, resp)
+}
+
+const timeout = 30 * time.Second // timeout for HTTP requests.
+
+// geter is an interface for HTTP GET requests.
+type geter interface {
+	get(context.Context, string) (*http.Response, error)
+}
+
+// sender is an interface for HTTP requests with any method and body.
+type sender interface {
+	send(ctx context.Context, method, url string,
+		body []byte) (*http.Response, error)
+}
+
+// get is a control structure implementing geter and sender.
+type get struct {
+	c      *http.Client
+	ts     TokenSource // ts provides tokens authorizing requests.
+	policy RetryPolicy // policy configures retrying of failed requests.
+	stats  retryStats
+	base   string // base is an URL replacing endPointURL, if set.
+}
+
+// get implements geter.
+func (g *get) get(ctx context.Context, url string) (*http.Response, error) {
+	return g.send(ctx, http.MethodGet, url, nil)
+}
+
+// send implements sender. Failed requests are retried according to
+// g.policy. If Web API responds with 401 Unauthorized, the cached token is
+// invalidated and the request is retried once with a new token.
+func (g *get) send(ctx context.Context, method, url string,
+	body []byte) (*http.Response, error) {
+	r, err := g.retry(ctx, method, url, body)
+	if err != nil || r.StatusCode != http.StatusUnauthorized {
+		return r, err
+	}
+	inv, ok := g.ts.(invalidator)
+	if !ok {
+		return r, nil
+	}
+	r.Body.Close()
+	inv.invalidate()
+	return g.retry(ctx, method, url, body)
+}
+
+// do sends an authorized request to url.
+func (g *get) do(ctx context.Context, method, url string,
+	body []byte) (*http.Response, error) {
+	if g.base != "" && strings.HasPrefix(url, endPointURL) {
+		url = g.base + strings.TrimPrefix(url, endPointURL)
+	}
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, rd)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.ts != nil {
+		tok, err := g.ts.Token()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", tok.header())
+	}
+	return g.c.Do(req)
+}
+
+// newGet returns a default implementation of geter using ts to authorize
+// requests. If ts is nil, requests are sent without Authorization header.
+func newGet(ts TokenSource) *get {
+	return &get{
+		ts:     ts,
+		policy: DefaultRetryPolicy,
+		c: &http.Client{
+			Transport: &http.Transport{
+				Dial: func(n, a string) (net.Conn, error) {
+					return net.DialTimeout(n, a, timeout)
+				},
+				TLSClientConfig: &tls.Config{},
+			},
+		},
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pblaszczyk/go.spotify"
+)
+
+// scopes is a list of scopes requested on login.
+var scopes = []string{
+	"user-read-private",
+	"user-read-email",
+	"user-library-read",
+	"user-library-modify",
+	"playlist-read-private",
+	"playlist-modify-public",
+	"playlist-modify-private",
+	"user-read-playback-state",
+	"user-modify-playback-state",
+	"user-read-currently-playing",
+	"user-read-recently-played",
+}
+
+func fail(msg string) {
+	fmt.Fprintf(os.Stderr, "[spotifycli]: %s\n", msg)
+	os.Exit(1)
+}
+
+func clientID() string {
+	id := os.Getenv("SPOTIFY_ID")
+	if id == "" {
+		fail("SPOTIFY_ID must be set")
+	}
+	return id
+}
+
+// profile returns name of profile provided as an optional argument
+// of the command, SPOTIFY_PROFILE or "default".
+func profile() string {
+	switch {
+	case len(os.Args) == 3:
+		return os.Args[2]
+	case len(os.Args) > 3:
+		usage()
+	}
+	return envProfile()
+}
+
+// envProfile returns SPOTIFY_PROFILE or "default".
+func envProfile() string {
+	if p := os.Getenv("SPOTIFY_PROFILE"); p != "" {
+		return p
+	}
+	return "default"
+}
+
+func newStore() *spotify.TokenStore {
+	s, err := spotify.NewTokenStore("")
+	handlerr(err)
+	return s
+}
+
+// newClient returns Client authorized as user logged in to the profile or,
+// if there is no such user, with client credentials.
+func newClient() *spotify.Client {
+	id := clientID()
+	ts, err := spotify.NewUserTokenSource(id, newStore(), envProfile())
+	if err == nil {
+		return spotify.NewClient(ts)
+	}
+	if !spotify.IsNoToken(err) {
+		handlerr(err)
+	}
+	secret := os.Getenv("SPOTIFY_SECRET")
+	if secret == "" {
+		fail("SPOTIFY_SECRET must be set or user must be logged in")
+	}
+	return spotify.NewClient(spotify.NewClientCredentials(id, secret))
+}
+
+// newUserClient returns Client authorized as user logged in to the profile.
+func newUserClient(prof string) *spotify.Client {
+	ts, err := spotify.NewUserTokenSource(clientID(), newStore(), prof)
+	if spotify.IsNoToken(err) {
+		fail(fmt.Sprintf("not logged in; run: spotifycli login %s", prof))
+	}
+	handlerr(err)
+	return spotify.NewClient(ts)
+}
+
+func newSearch() *spotify.Search {
+	return newClient().Search()
+}
+
+func login() {
+	prof := profile()
+	l := spotify.NewLogin(clientID(), scopes...)
+	l.Open = func(url string) error {
+		fmt.Printf("Open following URL in your browser to log in:\n\n%s\n\n", url)
+		browse(url)
+		return nil
+	}
+	tok, err := l.Run()
+	handlerr(err)
+	handlerr(newStore().Save(prof, tok))
+	whoamiProfile(prof)
+}
+
+func logout() {
+	if err := newStore().Delete(profile()); !spotify.IsNoToken(err) {
+		handlerr(err)
+	}
+}
+
+func whoami() {
+	whoamiProfile(profile())
+}
+
+func whoamiProfile(prof string) {
+	u, err := newUserClient(prof).Me(context.Background())
+	handlerr(err)
+	fmt.Printf("Profile: %s\nID:      %s\nName:    %s\nEmail:   %s\n"+
+		"Country: %s\nProduct: %s\n", prof, u.ID, u.Name, u.Email, u.Country,
+		u.Product)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/pblaszczyk/go.spotify"
+)
+
+// library runs user's library subcommand.
+func library() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := newUserClient(envProfile())
+	switch os.Args[2] {
+	case "tracks":
+		saved(c.SavedTracks(ctx, spotify.PageOpts{Limit: 50}),
+			func(t spotify.SavedTrack) (string, string, string) {
+				return t.AddedAt.Format("2006-01-02"), t.Name, t.URI
+			})
+	case "albums":
+		saved(c.SavedAlbums(ctx, spotify.PageOpts{Limit: 50}),
+			func(a spotify.SavedAlbum) (string, string, string) {
+				return a.AddedAt.Format("2006-01-02"), a.Name, a.URI
+			})
+	case "shows":
+		saved(c.SavedShows(ctx, spotify.PageOpts{Limit: 50}),
+			func(s spotify.SavedShow) (string, string, string) {
+				return s.AddedAt.Format("2006-01-02"), s.Name, s.URI
+			})
+	case "check":
+		u := uris(os.Args[3:])
+		res, err := c.LibraryContains(ctx, u...)
+		handlerr(err)
+		for i := range u {
+			fmt.Printf("%s: %t\n", u[i], res[i])
+		}
+	case "save":
+		handlerr(c.SaveToLibrary(ctx, uris(os.Args[3:])...))
+	case "remove":
+		handlerr(c.RemoveFromLibrary(ctx, uris(os.Args[3:])...))
+	default:
+		usage()
+	}
+}
+
+// saved displays all items of p, one per line, formatted with f.
+func saved[T any](p *spotify.Pager[T],
+	f func(T) (added, name, uri string)) {
+	for p.Next() {
+		for _, i := range p.Page() {
+			added, name, uri := f(i)
+			fmt.Printf("%s  %s (%s)\n", added, name, uri)
+		}
+	}
+	handlerr(p.Err())
+}
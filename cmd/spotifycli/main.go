@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/pblaszczyk/go.spotify"
 )
@@ -12,15 +17,102 @@ func usage() {
 	fmt.Printf(`spotifycli - commandline controller for Spotify desktop app.
 
 Usage:
-  spotifycli [commands] [args...]
+  spotifycli [--backend <dbus|connect>] [--device <ID>] [--player <name>]
+             [commands] [args...]
 
 Commands:
-  search             - Search for artist/album/track.
+  search             - Search for items of Spotify catalog.
        artist <name> - Search for artist.
        album  <name> - Search for album.
        track  <name> - Search for track.
+       playlist <name>
+                     - Search for playlist.
+       show <name>   - Search for show (podcast).
+       episode <name>
+                     - Search for show episode.
+       audiobook <name>
+                     - Search for audiobook.
+       all <name>    - Search for all types of items at once.
+       Filters, which have to precede <name>:
+       --artist, --album, --track, --genre <name>
+                     - Filter by artist/album/track name or genre.
+       --year <year> - Filter by year (1990) or range of years (1990-1999).
+       --isrc, --upc <code>
+                     - Filter tracks by ISRC or albums by UPC.
+       --new, --hipster
+                     - Only new or the least popular albums.
+       --or, --not <keywords>
+                     - Comma separated alternative or excluded keywords.
+  info <URI|URL>     - Show details of track, album, artist or playlist.
+                       Tracklist is shown for albums.
+  artist <URI|URL>   - Browse artist.
+       albums [groups]
+                     - Albums of artist, optionally restricted to comma
+                       separated groups: album,single,appears_on,compilation.
+       top [market]  - Top tracks of artist in market (default: US).
+       related       - Related artists.
+  features <URI|URL> - Show audio features of track.
+  playlist           - Manage playlists of logged in user.
+       create [--desc <text>] [--public=<bool>] <name>
+                     - Create playlist.
+       edit [--name <name>] [--desc <text>] [--public=<bool>]
+            [--collaborative=<bool>] <URI>
+                     - Change details of playlist.
+       add [--pos <n>] <URI> <item URI...>
+                     - Add tracks or episodes at position (default: end).
+       remove <URI> <item URI...>
+                     - Remove all occurrences of tracks or episodes.
+       move <URI> <start> <before> [length]
+                     - Move items starting at start before position before.
+       replace <URI> <item URI...>
+                     - Replace all items of playlist.
+  library            - Manage library of logged in user.
+       tracks, albums, shows
+                     - List saved items, the most recently saved first.
+       check <URI...>
+                     - Check whether items are saved.
+       save <URI...> - Save tracks, albums, shows or episodes.
+       remove <URI...>
+                     - Remove saved items.
+  open <URI|URL>     - Play Spotify URI, open.spotify.com URL or other URI.
+  play               - Start playing.
+  pause              - Pause playing.
+  stop               - Stop playing.
+  toggle             - Toggle playing.
+  next               - Play next track.
+  prev               - Play previous track.
+  seek <seconds>     - Move current position, which can be negative.
+  volume [+/-N|N%%]   - Show volume, change it by N points or set it to N%%.
+  shuffle on|off|toggle
+                     - Turn shuffle on or off.
+  repeat none|track|playlist
+                     - Set repeat mode.
+  status             - Current Status.
+  track              - Current track.
+  length             - Length of a current track.
+  devices            - List Spotify Connect devices.
+  transfer <ID>      - Transfer playback to Spotify Connect device.
+  queue <URI|URL>    - Add track or episode to the playback queue.
+  like               - Save current track in library.
+  radio [attr=value...]
+                     - Queue tracks recommended for current track. Audio
+                       attributes are tuned with [min_|max_|target_]<attr>,
+                       e.g. min_energy=0.6 tempo=120.
+  login [profile]    - Log in to Spotify account.
+  logout [profile]   - Remove stored credentials of Spotify account.
+  whoami [profile]   - Show logged in user.
 `)
 	platfusage()
+	fmt.Printf(`
+Environment:
+  SPOTIFY_ID         - Client ID of application used to access Web API.
+  SPOTIFY_SECRET     - Client secret of application used to access Web API.
+  SPOTIFY_PROFILE    - Name of profile used for logged in user.
+  SPOTIFY_BACKEND    - Playback backend: dbus (Linux default) or connect.
+  SPOTIFY_DEVICE     - ID of Spotify Connect device controlled by connect.
+  SPOTIFY_PLAYER     - Name of MPRIS player controlled by dbus, e.g. vlc;
+                       the most recently active one is used by default.
+`)
 	os.Exit(1)
 }
 
@@ -37,73 +129,219 @@ func newApp() *spotify.App {
 	return app
 }
 
-func searchArtist() {
-	s := spotify.NewSearch()
-	res, err := make(chan []spotify.Artist), make(chan error)
-	s.Artist(os.Args[3], res, err)
+// find runs search f for a query provided as an argument and displays
+// results. Search is cancelled on interrupt.
+func find[T any](f func(context.Context, string, chan<- []T) error,
+	q string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	res, errc := make(chan []T), make(chan error, 1)
+	go func() {
+		errc <- f(ctx, q, res)
+	}()
 	b := true
-LOOP:
-	for {
-		select {
-		case res := <-res:
-			disp(res, b)
-			b = false
-		case err := <-err:
-			handlerr(err)
-			break LOOP
-		}
+	for r := range res {
+		disp(r, b)
+		b = false
 	}
+	handlerr(<-errc)
 }
 
-func searchAlbum() {
-	s := spotify.NewSearch()
-	res, err := make(chan []spotify.Album), make(chan error)
-	s.Album(os.Args[3], res, err)
-	b := true
-LOOP:
-	for {
-		select {
-		case res := <-res:
-			disp(res, b)
-			b = false
-		case err := <-err:
-			handlerr(err)
-			break LOOP
-		}
+// findAll searches for all types of items with a single request and displays
+// the first page of results of each type.
+func findAll(q string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	res, err := newSearch().All(ctx, q, spotify.SearchAllTypes,
+		spotify.PageOpts{Limit: 5})
+	handlerr(err)
+	section("Artists", res.Artists)
+	section("Albums", res.Albums)
+	section("Tracks", res.Tracks)
+	section("Playlists", res.Playlists)
+	section("Shows", res.Shows)
+	section("Episodes", res.Episodes)
+	section("Audiobooks", res.Audiobooks)
+}
+
+// section displays the first page of p under a header.
+func section[T any](name string, p *spotify.Pager[T]) {
+	if !p.Next() {
+		handlerr(p.Err())
+		return
 	}
+	fmt.Printf("== %s (%d) ==\n", name, p.Total())
+	disp(p.Page(), true)
+	fmt.Printf("\n\n")
 }
 
-func searchTrack() {
-	s := spotify.NewSearch()
-	res, err := make(chan []spotify.Track), make(chan error)
-	s.Track(os.Args[3], res, err)
-	b := true
-LOOP:
-	for {
-		select {
-		case res := <-res:
-			disp(res, b)
-			b = false
-		case err := <-err:
-			handlerr(err)
-			break LOOP
-		}
+// query builds search query from arguments of search command.
+func query(args []string) string {
+	var q spotify.Query
+	var or, not string
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	fs.Usage = usage
+	fs.StringVar(&q.Artist, "artist", "", "")
+	fs.StringVar(&q.Album, "album", "", "")
+	fs.StringVar(&q.Track, "track", "", "")
+	fs.StringVar(&q.Genre, "genre", "", "")
+	fs.StringVar(&q.Year, "year", "", "")
+	fs.StringVar(&q.ISRC, "isrc", "", "")
+	fs.StringVar(&q.UPC, "upc", "", "")
+	fs.BoolVar(&q.New, "new", false, "")
+	fs.BoolVar(&q.Hipster, "hipster", false, "")
+	fs.StringVar(&or, "or", "", "")
+	fs.StringVar(&not, "not", "", "")
+	fs.Parse(args)
+	q.Keywords, q.Or, q.Not = strings.Join(fs.Args(), " "), split(or),
+		split(not)
+	handlerr(q.Validate())
+	return q.String()
+}
+
+// optarg returns i-th command line argument or empty string if there is
+// none.
+func optarg(i int) string {
+	if i < len(os.Args) {
+		return os.Args[i]
+	}
+	return ""
+}
+
+// split splits comma separated list.
+func split(s string) []string {
+	if s == "" {
+		return nil
 	}
+	return strings.Split(s, ",")
 }
 
 func search() {
+	q := query(os.Args[3:])
 	switch os.Args[2] {
 	case "artist":
-		searchArtist()
+		find(newSearch().ArtistContext, q)
 	case "album":
-		searchAlbum()
+		find(newSearch().AlbumContext, q)
 	case "track":
-		searchTrack()
+		find(newSearch().TrackContext, q)
+	case "playlist":
+		find(newSearch().PlaylistContext, q)
+	case "show":
+		find(newSearch().ShowContext, q)
+	case "episode":
+		find(newSearch().EpisodeContext, q)
+	case "audiobook":
+		find(newSearch().AudiobookContext, q)
+	case "all":
+		findAll(q)
+	default:
+		usage()
+	}
+	fmt.Println("")
+}
+
+// info displays details of item identified by URI or URL.
+func info() {
+	uri, err := spotify.ParseURI(os.Args[2])
+	handlerr(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := newClient()
+	switch uri.Kind() {
+	case spotify.KindTrack:
+		t, err := c.GetTrack(ctx, uri)
+		handlerr(err)
+		disp([]spotify.Track{t}, true)
+	case spotify.KindAlbum:
+		tl, err := c.Tracklist(ctx, uri)
+		handlerr(err)
+		disp([]spotify.Album{tl.Album}, true)
+		fmt.Printf("\n\n")
+		tracklist(tl)
+	case spotify.KindArtist:
+		a, err := c.GetArtist(ctx, uri)
+		handlerr(err)
+		disp([]spotify.Artist{a}, true)
+	case spotify.KindPlaylist:
+		p, err := c.GetPlaylist(ctx, uri)
+		handlerr(err)
+		disp([]spotify.Playlist{p}, true)
+	default:
+		handlerr(fmt.Errorf("unsupported URI: %q", uri))
 	}
 	fmt.Println("")
 }
 
+// artist displays albums, top tracks or related artists of artist.
+func artist() {
+	uri, err := spotify.ParseURI(os.Args[2])
+	handlerr(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := newClient()
+	switch arg := optarg(4); os.Args[3] {
+	case "albums":
+		p, err := c.ArtistAlbums(ctx, uri, split(arg), "", spotify.PageOpts{})
+		handlerr(err)
+		for b := true; p.Next(); b = false {
+			disp(p.Page(), b)
+		}
+		handlerr(p.Err())
+	case "top":
+		if arg == "" {
+			arg = "US"
+		}
+		t, err := c.TopTracks(ctx, uri, arg)
+		handlerr(err)
+		disp(t, true)
+	case "related":
+		a, err := c.RelatedArtists(ctx, uri)
+		handlerr(err)
+		disp(a, true)
+	default:
+		usage()
+	}
+	fmt.Println("")
+}
+
+// features displays audio features of track.
+func features() {
+	uri, err := spotify.ParseURI(os.Args[2])
+	handlerr(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	f, err := newClient().GetAudioFeatures(ctx, uri)
+	handlerr(err)
+	disp([]spotify.AudioFeatures{f}, true)
+	fmt.Printf("\n\nKey: %s (%s)\n", f.KeyName(), f.Camelot())
+}
+
+// tracklist displays tracks of album as a numbered list.
+func tracklist(tl *spotify.Tracklist) {
+	discs := tl.Discs()
+	for _, t := range tl.Tracks {
+		n := fmt.Sprintf("%2d.", t.TrackNumber)
+		if discs > 1 {
+			n = fmt.Sprintf("%d-%02d.", t.DiscNumber, t.TrackNumber)
+		}
+		e := ""
+		if t.Explicit {
+			e = " [E]"
+		}
+		fmt.Printf("%s %s (%s)%s\n", n, t.Name, minsec(t.Duration), e)
+	}
+	fmt.Printf("Total: %d tracks, %s", len(tl.Tracks), minsec(tl.Duration))
+}
+
+// minsec formats d as minutes and seconds.
+func minsec(d time.Duration) string {
+	s := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
 func main() {
+	globals()
 	if len(os.Args) == 1 {
 		usage()
 	}
@@ -119,12 +357,47 @@ func main() {
 		}
 		fmt.Println("Running")
 	case "search":
-		if len(os.Args) != 4 {
+		if len(os.Args) < 4 {
 			usage()
 		}
 		search()
+	case "info":
+		if len(os.Args) != 3 {
+			usage()
+		}
+		info()
+	case "artist":
+		if len(os.Args) < 4 {
+			usage()
+		}
+		artist()
+	case "features":
+		if len(os.Args) != 3 {
+			usage()
+		}
+		features()
+	case "playlist":
+		if len(os.Args) < 4 {
+			usage()
+		}
+		playlist()
+	case "library":
+		if len(os.Args) < 3 {
+			usage()
+		}
+		library()
+	case "login":
+		login()
+	case "logout":
+		logout()
+	case "whoami":
+		whoami()
 	default:
-		platform()
+		if f, ok := playerCmds[os.Args[1]]; ok {
+			f()
+		} else {
+			platform()
+		}
 	}
 }
 
@@ -137,12 +410,14 @@ func disp(r interface{}, b bool) {
 		b = false
 		for j, l := 0, reflect.ValueOf(r).Index(i).NumField(); j < l; j++ {
 			f := reflect.ValueOf(r).Index(i).Field(j)
-			if f.Kind() == reflect.Slice && f.Len() > 0 {
+			if f.Kind() == reflect.Slice && f.Len() > 0 &&
+				f.Type().Elem().Kind() == reflect.Struct {
 				fmt.Printf("%q\n", reflect.ValueOf(r).Index(i).Type().Field(j).Name)
 				disp(f.Interface(), true)
 			} else {
 				fmt.Printf("%q: %q",
-					reflect.ValueOf(r).Index(i).Type().Field(j).Name, f.String())
+					reflect.ValueOf(r).Index(i).Type().Field(j).Name,
+					fmt.Sprint(f.Interface()))
 			}
 			if j < l-1 {
 				fmt.Println("")
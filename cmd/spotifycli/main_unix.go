@@ -5,59 +5,31 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
 
 	"github.com/pblaszczyk/go.spotify"
 )
 
 func newDbus() *spotify.Dbus {
-	d, err := spotify.NewDbus()
+	d, err := spotify.NewDbus(mpris)
 	handlerr(err)
 	return d
 }
 
-func open() {
-	if len(os.Args) != 3 {
-		usage()
-	}
-	handlerr(newDbus().Open(spotify.URI(os.Args[2])))
-}
-
-func length() {
-	length, err := newDbus().Length()
-	handlerr(err)
-	fmt.Println(length)
+// registerPlayers registers Dbus backend controlling player selected by
+// --player.
+func registerPlayers() {
+	spotify.RegisterPlayer(spotify.PlayerDbus, func() (spotify.Player, error) {
+		return spotify.NewDbus(mpris)
+	})
 }
 
-func status() {
-	status, err := newDbus().Status()
+// players lists MPRIS media players, the one controlled by default first.
+func players() {
+	p, err := spotify.MprisPlayers()
 	handlerr(err)
-	fmt.Println(status)
-}
-
-func track() {
-	track, err := newDbus().Track()
-	handlerr(err)
-	fmt.Println(track)
-}
-
-func next() {
-	handlerr(newDbus().Next())
-}
-
-func prev() {
-	handlerr(newDbus().Prev())
-}
-
-func play() {
-	handlerr(newDbus().Play())
-}
-
-func stop() {
-	handlerr(newDbus().Stop())
-}
-
-func toggle() {
-	handlerr(newDbus().Toggle())
+	disp(p, true)
+	fmt.Println("")
 }
 
 func raise() {
@@ -73,29 +45,21 @@ func platform() {
 }
 
 var cmd2func = map[string]func(){
-	"next":   next,
-	"prev":   prev,
-	"open":   open,
-	"play":   play,
-	"stop":   stop,
-	"toggle": toggle,
-	"status": status,
-	"track":  track,
-	"length": length,
-	"raise":  raise,
+	"raise":   raise,
+	"players": players,
 }
 
 func platfusage() {
 	fmt.Printf(
-		`  play <URI>         - Play URI.
-  stop               - Stop playing.
-  toggle             - Toggle playing.
-  status             - Current Status.
-  track              - Current track.
-  length             - Length of a current track.
-  raise              - Raise the Spotify desktop app.
+		`  raise              - Raise the controlled player app.
+  players            - List MPRIS media players, the default one first.
   run                - Start Spotify destkop app.
   kill               - Kill Spotify destkop app.
   process            - Is Spotify destkop app running.
 `)
 }
+
+// browse tries to open url in a web browser.
+func browse(url string) {
+	exec.Command("xdg-open", url).Start()
+}
@@ -2,9 +2,20 @@
 
 package main
 
+import "os/exec"
+
+// registerPlayers registers platform specific backends.
+func registerPlayers() {
+}
+
 func platform() {
 	usage()
 }
 
 func platfusage() {
 }
+
+// browse tries to open url in a web browser.
+func browse(url string) {
+	exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
+}
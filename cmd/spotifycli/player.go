@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pblaszczyk/go.spotify"
+)
+
+// backend, device and mpris are set by global flags --backend, --device and
+// --player.
+var backend, device, mpris string
+
+// newPlayer returns playback backend selected by --backend, SPOTIFY_BACKEND
+// or the native backend of the platform.
+func newPlayer() spotify.Player {
+	registerPlayers()
+	spotify.RegisterPlayer(spotify.PlayerConnect,
+		func() (spotify.Player, error) { return newConnect(), nil })
+	p, err := spotify.NewPlayer(backend)
+	handlerr(err)
+	return p
+}
+
+// newConnect returns Connect backend controlling device selected by
+// --device, SPOTIFY_DEVICE or the active device.
+func newConnect() *spotify.Connect {
+	c := spotify.NewConnect(newUserClient(envProfile()))
+	c.Device = device
+	return c
+}
+
+func open() {
+	if len(os.Args) != 3 {
+		usage()
+	}
+	handlerr(newPlayer().Open(spotify.URI(os.Args[2])))
+}
+
+func length() {
+	length, err := newPlayer().Length()
+	handlerr(err)
+	fmt.Println(length)
+}
+
+func status() {
+	status, err := newPlayer().Status()
+	handlerr(err)
+	fmt.Println(status)
+}
+
+func track() {
+	track, err := newPlayer().Track()
+	handlerr(err)
+	fmt.Println(track)
+}
+
+// seek moves current position by number of seconds provided as argument.
+func seek() {
+	if len(os.Args) != 3 {
+		usage()
+	}
+	s, err := strconv.ParseFloat(os.Args[2], 64)
+	handlerr(err)
+	handlerr(newPlayer().Goto(time.Duration(s * float64(time.Second))))
+}
+
+// volume shows volume or sets it to N% or changes it by +N/-N percentage
+// points.
+func volume() {
+	p := newPlayer()
+	v, err := p.Volume()
+	handlerr(err)
+	switch len(os.Args) {
+	case 2:
+		fmt.Printf("%.0f%%\n", v*100)
+		return
+	case 3:
+	default:
+		usage()
+	}
+	arg := os.Args[2]
+	n, err := strconv.ParseFloat(strings.TrimSuffix(arg, "%"), 64)
+	handlerr(err)
+	if strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		n += v * 100
+	}
+	handlerr(p.SetVolume(math.Max(0, math.Min(100, n)) / 100))
+}
+
+// shuffle turns shuffle on, off or toggles it.
+func shuffle() {
+	if len(os.Args) != 3 {
+		usage()
+	}
+	p := newPlayer()
+	var on bool
+	switch os.Args[2] {
+	case "on":
+		on = true
+	case "off":
+	case "toggle":
+		s, err := p.Shuffle()
+		handlerr(err)
+		on = !s
+	default:
+		usage()
+	}
+	handlerr(p.SetShuffle(on))
+}
+
+// repeat sets loop mode of playback.
+func repeat() {
+	if len(os.Args) != 3 {
+		usage()
+	}
+	l, ok := map[string]spotify.Loop{
+		"none":     spotify.LoopNone,
+		"track":    spotify.LoopTrack,
+		"playlist": spotify.LoopPlaylist,
+	}[os.Args[2]]
+	if !ok {
+		usage()
+	}
+	handlerr(newPlayer().SetLoop(l))
+}
+
+// devices lists Spotify Connect devices.
+func devices() {
+	d, err := newConnect().Devices()
+	handlerr(err)
+	disp(d, true)
+	fmt.Println("")
+}
+
+// transfer transfers playback to Spotify Connect device.
+func transfer() {
+	if len(os.Args) != 3 {
+		usage()
+	}
+	handlerr(newConnect().Transfer(os.Args[2], true))
+}
+
+// queue adds item to the playback queue.
+func queue() {
+	if len(os.Args) != 3 {
+		usage()
+	}
+	uri, err := spotify.ParseURI(os.Args[2])
+	handlerr(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	handlerr(newUserClient(envProfile()).Queue(ctx, uri))
+}
+
+// radio queues tracks recommended for the currently played track. Audio
+// attributes of recommended tracks can be tuned with arguments in form
+// [min_|max_|target_]<attribute>=<value>, target is used if no prefix is
+// provided.
+func radio() {
+	t, err := newPlayer().Track()
+	handlerr(err)
+	uri, err := spotify.ParseURI(t.URI)
+	handlerr(err)
+	r := spotify.Recommendations{
+		SeedTracks: []spotify.URI{uri},
+		Limit:      20,
+		Min:        map[spotify.Attribute]float64{},
+		Max:        map[spotify.Attribute]float64{},
+		Target:     map[spotify.Attribute]float64{},
+	}
+	for _, arg := range os.Args[2:] {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) != 2 {
+			usage()
+		}
+		v, err := strconv.ParseFloat(kv[1], 64)
+		handlerr(err)
+		m, a := r.Target, kv[0]
+		for p, pm := range map[string]map[spotify.Attribute]float64{
+			"min_": r.Min, "max_": r.Max, "target_": r.Target,
+		} {
+			if strings.HasPrefix(a, p) {
+				m, a = pm, strings.TrimPrefix(a, p)
+			}
+		}
+		m[spotify.Attribute(a)] = v
+	}
+	handlerr(r.Validate())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := newUserClient(envProfile())
+	tracks, err := c.Recommend(ctx, r)
+	handlerr(err)
+	for _, t := range tracks {
+		handlerr(c.Queue(ctx, spotify.URI(t.URI)))
+		fmt.Printf("Queued: %s (%s)\n", t.Name, t.URI)
+	}
+}
+
+// like saves the currently played track in user's library.
+func like() {
+	t, err := newPlayer().Track()
+	handlerr(err)
+	uri, err := spotify.ParseURI(t.URI)
+	handlerr(err)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	handlerr(newUserClient(envProfile()).SaveToLibrary(ctx, uri))
+	fmt.Printf("Saved: %s (%s)\n", t.Name, uri)
+}
+
+// playerCmds are commands controlling playback with any backend.
+var playerCmds = map[string]func(){
+	"next":     func() { handlerr(newPlayer().Next()) },
+	"prev":     func() { handlerr(newPlayer().Prev()) },
+	"play":     func() { handlerr(newPlayer().Play()) },
+	"pause":    func() { handlerr(newPlayer().Pause()) },
+	"stop":     func() { handlerr(newPlayer().Stop()) },
+	"toggle":   func() { handlerr(newPlayer().Toggle()) },
+	"open":     open,
+	"status":   status,
+	"track":    track,
+	"length":   length,
+	"seek":     seek,
+	"volume":   volume,
+	"shuffle":  shuffle,
+	"repeat":   repeat,
+	"devices":  devices,
+	"transfer": transfer,
+	"queue":    queue,
+	"radio":    radio,
+	"like":     like,
+}
+
+// globals parses global flags preceding command and removes them from
+// os.Args. Flags default to SPOTIFY_BACKEND, SPOTIFY_DEVICE and
+// SPOTIFY_PLAYER.
+func globals() {
+	fs := flag.NewFlagSet("spotifycli", flag.ExitOnError)
+	fs.Usage = usage
+	fs.StringVar(&backend, "backend", os.Getenv("SPOTIFY_BACKEND"), "")
+	fs.StringVar(&device, "device", os.Getenv("SPOTIFY_DEVICE"), "")
+	fs.StringVar(&mpris, "player", os.Getenv("SPOTIFY_PLAYER"), "")
+	fs.Parse(os.Args[1:])
+	os.Args = append(os.Args[:1], fs.Args()...)
+}
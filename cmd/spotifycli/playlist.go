@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/pblaszczyk/go.spotify"
+)
+
+// playlist runs playlist management subcommand.
+func playlist() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	c := newUserClient(envProfile())
+	switch os.Args[2] {
+	case "create":
+		d, args := details(os.Args[3:])
+		if d.Name == "" && len(args) > 0 {
+			d.Name = args[0]
+		}
+		if d.Name == "" {
+			usage()
+		}
+		u, err := c.Me(ctx)
+		handlerr(err)
+		p, err := c.CreatePlaylist(ctx, u.ID, d)
+		handlerr(err)
+		disp([]spotify.Playlist{p}, true)
+		fmt.Println("")
+	case "edit":
+		d, args := details(os.Args[3:])
+		handlerr(c.EditPlaylist(ctx, playlistURI(args), d))
+	case "add":
+		fs := flag.NewFlagSet("add", flag.ExitOnError)
+		fs.Usage = usage
+		pos := fs.Int("pos", -1, "")
+		fs.Parse(os.Args[3:])
+		snap, err := c.AddItems(ctx, playlistURI(fs.Args()), *pos,
+			uris(fs.Args()[1:])...)
+		handlerr(err)
+		fmt.Println(snap)
+	case "remove":
+		args := os.Args[3:]
+		items := make([]spotify.PlaylistItem, len(args)-1)
+		for i, u := range uris(args[1:]) {
+			items[i].URI = u
+		}
+		snap, err := c.RemoveItems(ctx, playlistURI(args), "", items...)
+		handlerr(err)
+		fmt.Println(snap)
+	case "move":
+		args := os.Args[3:]
+		if len(args) < 3 || len(args) > 4 {
+			usage()
+		}
+		start, before, length := atoi(args[1]), atoi(args[2]), 1
+		if len(args) == 4 {
+			length = atoi(args[3])
+		}
+		snap, err := c.ReorderItems(ctx, playlistURI(args), start, length,
+			before, "")
+		handlerr(err)
+		fmt.Println(snap)
+	case "replace":
+		args := os.Args[3:]
+		snap, err := c.ReplaceItems(ctx, playlistURI(args),
+			uris(args[1:])...)
+		handlerr(err)
+		fmt.Println(snap)
+	default:
+		usage()
+	}
+}
+
+// details parses playlist details from args and returns remaining
+// arguments.
+func details(args []string) (spotify.PlaylistDetails, []string) {
+	var d spotify.PlaylistDetails
+	fs := flag.NewFlagSet("playlist", flag.ExitOnError)
+	fs.Usage = usage
+	fs.StringVar(&d.Name, "name", "", "")
+	desc := fs.String("desc", "", "")
+	public := fs.String("public", "", "")
+	collab := fs.String("collaborative", "", "")
+	fs.Parse(args)
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "desc":
+			d.Description = desc
+		case "public":
+			d.Public = parseBool(*public)
+		case "collaborative":
+			d.Collaborative = parseBool(*collab)
+		}
+	})
+	return d, fs.Args()
+}
+
+// playlistURI parses the first of args as playlist URI.
+func playlistURI(args []string) spotify.URI {
+	if len(args) == 0 {
+		usage()
+	}
+	u, err := spotify.ParseURI(args[0])
+	handlerr(err)
+	return u
+}
+
+// uris parses args as URIs of playlist items.
+func uris(args []string) []spotify.URI {
+	res := make([]spotify.URI, len(args))
+	for i := range args {
+		u, err := spotify.ParseURI(args[i])
+		handlerr(err)
+		res[i] = u
+	}
+	return res
+}
+
+func atoi(s string) int {
+	n, err := strconv.Atoi(s)
+	handlerr(err)
+	return n
+}
+
+func parseBool(s string) *bool {
+	b, err := strconv.ParseBool(s)
+	handlerr(err)
+	return &b
+}
@@ -0,0 +1,362 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Loop is a loop (repeat) mode of a player.
+type Loop string
+
+const (
+	// LoopNone plays items once.
+	LoopNone Loop = "None"
+
+	// LoopTrack repeats the current track.
+	LoopTrack Loop = "Track"
+
+	// LoopPlaylist repeats the current playlist or album.
+	LoopPlaylist Loop = "Playlist"
+)
+
+// valid reports whether l is a valid loop mode.
+func (l Loop) valid() bool {
+	return l == LoopNone || l == LoopTrack || l == LoopPlaylist
+}
+
+// connectLoops maps loop modes to repeat states of Web API.
+var connectLoops = map[Loop]string{
+	LoopNone:     "off",
+	LoopTrack:    "track",
+	LoopPlaylist: "context",
+}
+
+// Device is a Spotify Connect device.
+type Device struct {
+	ID     string // ID is an ID of the device.
+	Name   string // Name is a human readable name of the device.
+	Type   string // Type is a type of the device, e.g. Computer.
+	Active bool   // Active reports whether the device is the active one.
+	Volume int    // Volume in percent, -1 if device has no volume control.
+}
+
+// playerURL is an URL of player endpoints.
+const playerURL = endPointURL + "me/player"
+
+// Connect controls playback on Spotify Connect devices through Web API. It
+// requires Client authorized by logged in user with Premium subscription.
+type Connect struct {
+	// Device is an ID of controlled device. The active device is controlled
+	// if it is empty.
+	Device string
+	// Timeout is a timeout of a single Web API request.
+	Timeout time.Duration
+
+	c *Client
+}
+
+// NewConnect returns Connect sending requests through c.
+func NewConnect(c *Client) *Connect {
+	return &Connect{c: c, Timeout: timeout}
+}
+
+// Play resumes playback.
+func (c *Connect) Play() error {
+	return c.do(http.MethodPut, "/play", nil, nil, nil)
+}
+
+// Pause pauses playback.
+func (c *Connect) Pause() error {
+	return c.do(http.MethodPut, "/pause", nil, nil, nil)
+}
+
+// Stop pauses playback, as Spotify Connect has no notion of stopping.
+func (c *Connect) Stop() error {
+	return c.Pause()
+}
+
+// Toggle pauses playback if playing or resumes it otherwise.
+func (c *Connect) Toggle() error {
+	st, err := c.state()
+	if err != nil {
+		return err
+	}
+	if st != nil && st.Playing {
+		return c.Pause()
+	}
+	return c.Play()
+}
+
+// Next plays next track.
+func (c *Connect) Next() error {
+	return c.do(http.MethodPost, "/next", nil, nil, nil)
+}
+
+// Prev plays previous track.
+func (c *Connect) Prev() error {
+	return c.do(http.MethodPost, "/previous", nil, nil, nil)
+}
+
+// Goto moves current position by offset, which can be negative.
+func (c *Connect) Goto(offset time.Duration) error {
+	pos, err := c.Pos()
+	if err != nil {
+		return err
+	}
+	if pos += offset; pos < 0 {
+		pos = 0
+	}
+	return c.SetPos(pos)
+}
+
+// SetPos sets current position in the current track.
+func (c *Connect) SetPos(pos time.Duration) error {
+	if pos < 0 {
+		return errorf("invalid position: %v", pos)
+	}
+	return c.do(http.MethodPut, "/seek", url.Values{
+		"position_ms": {strconv.FormatInt(int64(pos/time.Millisecond), 10)},
+	}, nil, nil)
+}
+
+// Open starts playing item with URI. Tracks and episodes are played alone,
+// while albums, playlists, artists, shows and audiobooks are played as
+// a context.
+func (c *Connect) Open(uri URI) error {
+	u, err := ParseURI(string(uri))
+	if err != nil {
+		return err
+	}
+	req := playReq{}
+	switch u.Kind() {
+	case KindTrack, KindEpisode:
+		req.URIs = []string{string(u)}
+	case KindAlbum, KindPlaylist, KindArtist, KindShow, KindAudiobook:
+		req.Context = string(u)
+	default:
+		return errorf("unsupported URI: %q", uri)
+	}
+	return c.do(http.MethodPut, "/play", nil, req, nil)
+}
+
+// Queue adds track or episode identified by uri to the playback queue.
+func (c *Connect) Queue(uri URI) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	return c.c.queue(ctx, uri, c.Device)
+}
+
+// Track returns currently played track.
+func (c *Connect) Track() (Track, error) {
+	st, err := c.state()
+	if err != nil {
+		return Track{}, err
+	}
+	if st == nil || st.Item == nil {
+		return Track{}, errorf("no track is playing")
+	}
+	return st.Item.conv(), nil
+}
+
+// Status returns status of playback.
+func (c *Connect) Status() (Status, error) {
+	st, err := c.state()
+	switch {
+	case err != nil:
+		return Status(""), err
+	case st == nil || st.Item == nil:
+		return Stopped, nil
+	case st.Playing:
+		return Playing, nil
+	}
+	return Paused, nil
+}
+
+// Length returns length of current track.
+func (c *Connect) Length() (time.Duration, error) {
+	t, err := c.Track()
+	return t.Duration, err
+}
+
+// Pos returns current position.
+func (c *Connect) Pos() (time.Duration, error) {
+	st, err := c.state()
+	if err != nil || st == nil {
+		return 0, err
+	}
+	return time.Duration(st.Progress) * time.Millisecond, nil
+}
+
+// Volume returns volume of the device in range 0-1.
+func (c *Connect) Volume() (float64, error) {
+	st, err := c.state()
+	if err != nil {
+		return 0, err
+	}
+	if st == nil || st.Device.Volume == nil {
+		return 0, errorf("volume is not available")
+	}
+	return float64(*st.Device.Volume) / 100, nil
+}
+
+// SetVolume sets volume of the device in range 0-1.
+func (c *Connect) SetVolume(v float64) error {
+	if v < 0 || v > 1 {
+		return errorf("invalid volume: %v", v)
+	}
+	return c.do(http.MethodPut, "/volume", url.Values{
+		"volume_percent": {strconv.Itoa(int(v*100 + 0.5))},
+	}, nil, nil)
+}
+
+// Shuffle reports whether shuffle is on.
+func (c *Connect) Shuffle() (bool, error) {
+	st, err := c.state()
+	if err != nil || st == nil {
+		return false, err
+	}
+	return st.Shuffle, nil
+}
+
+// SetShuffle turns shuffle on or off.
+func (c *Connect) SetShuffle(on bool) error {
+	return c.do(http.MethodPut, "/shuffle", url.Values{
+		"state": {strconv.FormatBool(on)},
+	}, nil, nil)
+}
+
+// Loop returns loop mode of playback.
+func (c *Connect) Loop() (Loop, error) {
+	st, err := c.state()
+	if err != nil || st == nil {
+		return LoopNone, err
+	}
+	for l, s := range connectLoops {
+		if s == st.Repeat {
+			return l, nil
+		}
+	}
+	return LoopNone, errorf("unsupported repeat state: %q", st.Repeat)
+}
+
+// SetLoop sets loop mode of playback.
+func (c *Connect) SetLoop(l Loop) error {
+	s, ok := connectLoops[l]
+	if !ok {
+		return errorf("invalid loop mode: %q", l)
+	}
+	return c.do(http.MethodPut, "/repeat", url.Values{"state": {s}}, nil,
+		nil)
+}
+
+// CanPlay reports whether playback can be resumed.
+func (c *Connect) CanPlay() (bool, error) {
+	return c.allows("resuming")
+}
+
+// CanPause reports whether playback can be paused.
+func (c *Connect) CanPause() (bool, error) {
+	return c.allows("pausing")
+}
+
+// CanNext reports whether next track can be played.
+func (c *Connect) CanNext() (bool, error) {
+	return c.allows("skipping_next")
+}
+
+// CanPrev reports whether previous track can be played.
+func (c *Connect) CanPrev() (bool, error) {
+	return c.allows("skipping_prev")
+}
+
+// CanSeek reports whether current position can be changed.
+func (c *Connect) CanSeek() (bool, error) {
+	return c.allows("seeking")
+}
+
+// CanControl reports whether the device can be controlled, i.e. it is the
+// active one.
+func (c *Connect) CanControl() (bool, error) {
+	st, err := c.state()
+	return st != nil, err
+}
+
+// allows reports whether action is allowed on the active device.
+func (c *Connect) allows(action string) (bool, error) {
+	st, err := c.state()
+	if err != nil || st == nil {
+		return false, err
+	}
+	return !st.Actions.Disallows[action], nil
+}
+
+// Devices returns devices available for playback.
+func (c *Connect) Devices() ([]Device, error) {
+	var resp struct {
+		Devices []device `json:"devices"`
+	}
+	if err := c.send(http.MethodGet, playerURL+"/devices", nil,
+		&resp); err != nil {
+		return nil, err
+	}
+	res := make([]Device, len(resp.Devices))
+	for i, d := range resp.Devices {
+		res[i] = d.conv()
+	}
+	return res, nil
+}
+
+// Transfer transfers playback to device with id and makes it the device
+// controlled by c. If play is true, playback is started on the device.
+func (c *Connect) Transfer(id string, play bool) error {
+	if id == "" {
+		return errorf("transfer: empty device ID")
+	}
+	if err := c.send(http.MethodPut, playerURL, transferReq{
+		Devices: []string{id}, Play: play,
+	}, nil); err != nil {
+		return err
+	}
+	c.Device = id
+	return nil
+}
+
+// state returns state of playback. It returns nil if there is no active
+// device or c.Device is not the active one.
+func (c *Connect) state() (*playerState, error) {
+	var st *playerState
+	if err := c.do(http.MethodGet, "", nil, nil, &st); err != nil {
+		return nil, err
+	}
+	if st != nil && c.Device != "" && st.Device.ID != c.Device {
+		return nil, nil
+	}
+	return st, nil
+}
+
+// do sends request to player endpoint at path with query parameters v
+// targeting c.Device.
+func (c *Connect) do(method, path string, v url.Values, req,
+	resp interface{}) error {
+	if c.Device != "" && method != http.MethodGet {
+		if v == nil {
+			v = url.Values{}
+		}
+		v.Set("device_id", c.Device)
+	}
+	u := playerURL + path
+	if len(v) > 0 {
+		u += "?" + v.Encode()
+	}
+	return c.send(method, u, req, resp)
+}
+
+// send sends request to url with timeout of c.
+func (c *Connect) send(method, url string, req, resp interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+	return c.c.sendJSON(ctx, method, url, req, resp)
+}
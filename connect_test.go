@@ -0,0 +1,194 @@
+package spotify
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPI is a fake Web API server recording requests to player endpoints.
+type fakeAPI struct {
+	sync.Mutex
+	*httptest.Server
+	state string   // state is a response of player state endpoint.
+	reqs  []string // reqs are recorded requests: method, URI and body.
+}
+
+func newFakeAPI(t *testing.T, state string) *fakeAPI {
+	f := &fakeAPI{state: state}
+	f.Server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			f.Lock()
+			defer f.Unlock()
+			switch r.URL.Path {
+			case "/v1/me/player":
+				if r.Method == http.MethodGet {
+					if f.state == "" {
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					w.Write([]byte(f.state))
+					return
+				}
+			case "/v1/me/player/devices":
+				w.Write([]byte(`{"devices":[{"id":"d1","name":"Desk",` +
+					`"type":"Computer","is_active":true,"volume_percent":40},` +
+					`{"id":"d2","name":"TV","type":"TV","is_active":false,` +
+					`"volume_percent":null}]}`))
+				return
+			case "/v1/me/player/fail":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"status":404,` +
+					`"message":"Player command failed: No active device found"}}`))
+				return
+			}
+			f.reqs = append(f.reqs, r.Method+" "+r.URL.RequestURI()+" "+
+				string(b))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeAPI) connect() *Connect {
+	c := NewClient(nil)
+	c.SetEndpoint(f.URL + "/v1")
+	return NewConnect(c)
+}
+
+const testState = `{"device":{"id":"d1","name":"Desk","type":"Computer",` +
+	`"is_active":true,"volume_percent":40},"shuffle_state":true,` +
+	`"repeat_state":"context","progress_ms":30000,"is_playing":true,` +
+	`"actions":{"disallows":{"resuming":true}},` +
+	`"item":{"uri":"spotify:track:6crBy2sODw2HS53xquM6us","name":"Tribute",` +
+	`"duration_ms":248053,"album":{"uri":` +
+	`"spotify:album:1AckkxSo39144vOBrJ1GkS","name":"Tenacious D"},` +
+	`"artists":[{"uri":"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh",` +
+	`"name":"Tenacious D"}]}}`
+
+func TestConnectCommands(t *testing.T) {
+	t.Parallel()
+	f := newFakeAPI(t, testState)
+	c := f.connect()
+	for i, fn := range []func() error{
+		c.Play,
+		c.Pause,
+		c.Next,
+		c.Prev,
+		c.Toggle,
+		func() error { return c.Goto(-45 * time.Second) },
+		func() error { return c.SetPos(90 * time.Second) },
+		func() error { return c.SetVolume(0.55) },
+		func() error { return c.SetShuffle(false) },
+		func() error { return c.SetLoop(LoopTrack) },
+		func() error { return c.Open("spotify:track:6crBy2sODw2HS53xquM6us") },
+		func() error {
+			return c.Open("https://open.spotify.com/album/1AckkxSo39144vOBrJ1GkS")
+		},
+		func() error { return c.Queue("spotify:track:6crBy2sODw2HS53xquM6us") },
+		func() error { return c.Transfer("d2", true) },
+		c.Play,
+		func() error { return c.Queue("spotify:episode:512ojhOuo1ktJprKbVcKyQ") },
+	} {
+		if err := fn(); err != nil {
+			t.Fatalf("want err=nil; got %q (%d)", err, i)
+		}
+	}
+	want := []string{
+		"PUT /v1/me/player/play ",
+		"PUT /v1/me/player/pause ",
+		"POST /v1/me/player/next ",
+		"POST /v1/me/player/previous ",
+		"PUT /v1/me/player/pause ",
+		"PUT /v1/me/player/seek?position_ms=0 ",
+		"PUT /v1/me/player/seek?position_ms=90000 ",
+		"PUT /v1/me/player/volume?volume_percent=55 ",
+		"PUT /v1/me/player/shuffle?state=false ",
+		"PUT /v1/me/player/repeat?state=track ",
+		`PUT /v1/me/player/play {"uris":["spotify:track:6crBy2sODw2HS53xquM6us"]}`,
+		`PUT /v1/me/player/play {"context_uri":"spotify:album:1AckkxSo39144vOBrJ1GkS"}`,
+		"POST /v1/me/player/queue?uri=spotify%3Atrack%3A6crBy2sODw2HS53xquM6us ",
+		`PUT /v1/me/player {"device_ids":["d2"],"play":true}`,
+		"PUT /v1/me/player/play?device_id=d2 ",
+		"POST /v1/me/player/queue?uri=spotify%3Aepisode%3A512ojhOuo1ktJprKbVcKyQ&device_id=d2 ",
+	}
+	if !reflect.DeepEqual(f.reqs, want) {
+		t.Errorf("want requests:\n%q\ngot:\n%q", want, f.reqs)
+	}
+}
+
+func TestConnectState(t *testing.T) {
+	t.Parallel()
+	c := newFakeAPI(t, testState).connect()
+	tr, err := c.Track()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if tr.URI != "spotify:track:6crBy2sODw2HS53xquM6us" ||
+		tr.Duration != 248053*time.Millisecond {
+		t.Errorf("invalid track: %v", tr)
+	}
+	if st, err := c.Status(); err != nil || st != Playing {
+		t.Errorf("want status=Playing; got %q, %v", st, err)
+	}
+	if p, err := c.Pos(); err != nil || p != 30*time.Second {
+		t.Errorf("want pos=30s; got %v, %v", p, err)
+	}
+	if v, err := c.Volume(); err != nil || v != 0.4 {
+		t.Errorf("want volume=0.4; got %v, %v", v, err)
+	}
+	if s, err := c.Shuffle(); err != nil || !s {
+		t.Errorf("want shuffle=true; got %t, %v", s, err)
+	}
+	if l, err := c.Loop(); err != nil || l != LoopPlaylist {
+		t.Errorf("want loop=%q; got %q, %v", LoopPlaylist, l, err)
+	}
+	for name, f := range map[string]func() (bool, error){
+		"CanPlay": c.CanPlay, "CanPause": c.CanPause, "CanNext": c.CanNext,
+		"CanSeek": c.CanSeek, "CanControl": c.CanControl,
+	} {
+		if ok, err := f(); err != nil || ok != (name != "CanPlay") {
+			t.Errorf("want %s=%t; got %t, %v", name, name != "CanPlay", ok, err)
+		}
+	}
+	d, err := c.Devices()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := []Device{
+		{ID: "d1", Name: "Desk", Type: "Computer", Active: true, Volume: 40},
+		{ID: "d2", Name: "TV", Type: "TV", Volume: -1},
+	}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("want devices=%v; got %v", want, d)
+	}
+}
+
+func TestConnectNoDevice(t *testing.T) {
+	t.Parallel()
+	c := newFakeAPI(t, "").connect()
+	if st, err := c.Status(); err != nil || st != Stopped {
+		t.Errorf("want status=Stopped; got %q, %v", st, err)
+	}
+	if _, err := c.Track(); err == nil {
+		t.Error("want err!=nil for no track")
+	}
+	if ok, err := c.CanControl(); err != nil || ok {
+		t.Errorf("want CanControl=false; got %t, %v", ok, err)
+	}
+	if err := c.SetVolume(1.5); err == nil {
+		t.Error("want err!=nil for invalid volume")
+	}
+	if err := c.SetLoop("Forever"); err == nil {
+		t.Error("want err!=nil for invalid loop")
+	}
+	err := c.do(http.MethodPut, "/fail", nil, nil, nil)
+	if e, ok := err.(webError); !ok || e.Err.Status != 404 {
+		t.Errorf("want webError with status 404; got %v", err)
+	}
+}
@@ -1,46 +1,257 @@
 package spotify
 
+import (
+	"math"
+	"time"
+)
+
 // conv converts data structures from one format to another in a following way:
-// - artistResp -> []Artist
-// - albumResp  -> []Album
-// - trackResp  -> []Track
+// - artistResp    -> []Artist
+// - albumResp     -> []Album
+// - trackResp     -> []Track
+// - playlistResp  -> []Playlist
+// - showResp      -> []Show
+// - episodeResp   -> []Episode
+// - audiobookResp -> []Audiobook
 // If different type is provided as argument, function panics.
 func conv(d interface{}) interface{} {
 	switch d := d.(type) {
 	case *artistResp:
-		var res []Artist
-		for i := range d.Artists.Items {
-			res = append(res, Artist{
-				URI: d.Artists.Items[i].URI, Name: d.Artists.Items[i].Name,
-			})
-		}
-		return res
+		return d.Artists.Items.conv()
 	case *albumResp:
 		var res []Album
 		for i := range d.Albums.Items {
-			res = append(res, Album{
-				URI: d.Albums.Items[i].URI, Name: d.Albums.Items[i].Name,
-			})
+			res = append(res, d.Albums.Items[i].conv())
 		}
 		return res
 	case *trackResp:
 		var res []Track
 		for i := range d.Tracks.Items {
-			var a []Artist
-			for j := range d.Tracks.Items[i].Artists {
-				a = append(a, Artist{
-					URI:  d.Tracks.Items[i].Artists[j].URI,
-					Name: d.Tracks.Items[i].Artists[j].Name,
-				})
-			}
-			res = append(res, Track{
-				URI: d.Tracks.Items[i].URI, Name: d.Tracks.Items[i].Name,
-				AlbumURI:  d.Tracks.Items[i].Album.URI,
-				AlbumName: d.Tracks.Items[i].Album.Name, Artists: a,
-			})
+			res = append(res, d.Tracks.Items[i].conv())
 		}
 		return res
+	case *playlistResp:
+		return d.Playlists.Items.conv()
+	case *showResp:
+		return d.Shows.Items.conv()
+	case *episodeResp:
+		return d.Episodes.Items.conv()
+	case *audiobookResp:
+		return d.Audiobooks.Items.conv()
 	default:
 		panic("sscc: unsupported data format")
 	}
 }
+
+// conv converts artist to Artist.
+func (a artist) conv() Artist {
+	return Artist{
+		URI: a.URI, Name: a.Name, Genres: a.Genres,
+		Popularity: a.Popularity, Followers: a.Followers.Total,
+		Images: a.Images.conv(),
+	}
+}
+
+// conv converts artists to []Artist.
+func (a artists) conv() (res []Artist) {
+	for i := range a {
+		res = append(res, a[i].conv())
+	}
+	return
+}
+
+// conv converts images to []Image.
+func (im images) conv() (res []Image) {
+	for _, i := range im {
+		res = append(res, Image{URL: i.URL, Width: i.Width, Height: i.Height})
+	}
+	return
+}
+
+// conv converts simplified album to Album.
+func (a album) conv() Album {
+	return Album{
+		URI: a.URI, Name: a.Name, Type: a.AlbumType, Tracks: a.TotalTracks,
+		ReleaseDate: a.ReleaseDate, Images: a.Images.conv(),
+		ReleaseDatePrecision: a.ReleaseDatePrecision, Group: a.AlbumGroup,
+	}
+}
+
+// conv converts full album to Album.
+func (a *albumFull) conv() Album {
+	b := a.album.conv()
+	a.fill(&b)
+	return b
+}
+
+// fill fills in b with data of full album a.
+func (a *albumFull) fill(b *Album) {
+	b.Artists = a.Artists.conv()
+	b.Label, b.Popularity, b.Genres = a.Label, a.Popularity, a.Genres
+	b.UPC = a.ExternalIDs.UPC
+	if b.Type == "" {
+		b.Type = a.AlbumType
+	}
+	if b.Tracks == 0 {
+		b.Tracks = a.TotalTracks
+	}
+	if b.ReleaseDate == "" {
+		b.ReleaseDate, b.ReleaseDatePrecision = a.ReleaseDate,
+			a.ReleaseDatePrecision
+	}
+	if b.Images == nil {
+		b.Images = a.Images.conv()
+	}
+}
+
+// conv converts trackData to Track.
+func (t trackData) conv() Track {
+	return Track{
+		URI: t.URI, Name: t.Name, AlbumURI: t.Album.URI,
+		AlbumName: t.Album.Name, Artists: t.Artists.conv(),
+		Duration:   time.Duration(t.DurationMs) * time.Millisecond,
+		Popularity: t.Popularity, Explicit: t.Explicit,
+		TrackNumber: t.TrackNumber, DiscNumber: t.DiscNumber,
+		ISRC: t.ExternalIDs.ISRC,
+	}
+}
+
+// names returns names of persons p.
+func names(p []person) []string {
+	var res []string
+	for i := range p {
+		res = append(res, p[i].Name)
+	}
+	return res
+}
+
+// conv converts playlists to []Playlist skipping null items.
+func (p playlists) conv() (res []Playlist) {
+	for _, p := range p {
+		if p == nil {
+			continue
+		}
+		res = append(res, Playlist{
+			URI: p.URI, Name: p.Name, Description: p.Description,
+			Owner: User{
+				ID: p.Owner.ID, URI: p.Owner.URI, Name: p.Owner.DisplayName,
+			},
+			Public: p.Public, Tracks: p.Tracks.Total,
+		})
+	}
+	return
+}
+
+// conv converts shows to []Show skipping null items.
+func (s shows) conv() (res []Show) {
+	for _, s := range s {
+		if s == nil {
+			continue
+		}
+		res = append(res, Show{
+			URI: s.URI, Name: s.Name, Publisher: s.Publisher,
+			Description: s.Description, Explicit: s.Explicit,
+			Episodes: s.TotalEpisodes,
+		})
+	}
+	return
+}
+
+// conv converts episodes to []Episode skipping null items.
+func (e episodes) conv() (res []Episode) {
+	for _, e := range e {
+		if e == nil {
+			continue
+		}
+		res = append(res, Episode{
+			URI: e.URI, Name: e.Name, Description: e.Description,
+			Duration:    time.Duration(e.DurationMs) * time.Millisecond,
+			ReleaseDate: e.ReleaseDate, Explicit: e.Explicit,
+		})
+	}
+	return
+}
+
+// conv converts audiobooks to []Audiobook skipping null items.
+func (a audiobooks) conv() (res []Audiobook) {
+	for _, a := range a {
+		if a == nil {
+			continue
+		}
+		res = append(res, Audiobook{
+			URI: a.URI, Name: a.Name, Authors: names(a.Authors),
+			Narrators: names(a.Narrators), Publisher: a.Publisher,
+			Chapters: a.TotalChapters,
+		})
+	}
+	return
+}
+
+// seconds converts number of seconds to time.Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Round(s * float64(time.Second)))
+}
+
+// conv converts audioFeatures to AudioFeatures.
+func (f *audioFeatures) conv() AudioFeatures {
+	return AudioFeatures{
+		URI: f.URI, Duration: time.Duration(f.DurationMs) * time.Millisecond,
+		Key: f.Key, Mode: f.Mode, Tempo: f.Tempo,
+		TimeSignature: f.TimeSignature, Loudness: f.Loudness,
+		Acousticness: f.Acousticness, Danceability: f.Danceability,
+		Energy: f.Energy, Instrumentalness: f.Instrumentalness,
+		Liveness: f.Liveness, Speechiness: f.Speechiness, Valence: f.Valence,
+	}
+}
+
+// conv converts interval to Interval.
+func (i interval) conv() Interval {
+	return Interval{
+		Start: seconds(i.Start), Duration: seconds(i.Duration),
+		Confidence: i.Confidence,
+	}
+}
+
+// intervals converts in to []Interval.
+func intervals(in []interval) (res []Interval) {
+	for i := range in {
+		res = append(res, in[i].conv())
+	}
+	return
+}
+
+// conv converts audioAnalysis to AudioAnalysis.
+func (a *audioAnalysis) conv() *AudioAnalysis {
+	t := a.Track
+	res := &AudioAnalysis{
+		Duration: seconds(t.Duration), Key: t.Key, Mode: t.Mode,
+		Tempo: t.Tempo, TimeSignature: t.TimeSignature, Loudness: t.Loudness,
+		Bars: intervals(a.Bars), Beats: intervals(a.Beats),
+		Tatums: intervals(a.Tatums),
+	}
+	for _, s := range a.Sections {
+		res.Sections = append(res.Sections, Section{
+			Interval: s.conv(), Key: s.Key, Mode: s.Mode, Tempo: s.Tempo,
+			TimeSignature: s.TimeSignature, Loudness: s.Loudness,
+		})
+	}
+	for _, s := range a.Segments {
+		res.Segments = append(res.Segments, Segment{
+			Interval: s.conv(), LoudnessStart: s.LoudnessStart,
+			LoudnessMax:     s.LoudnessMax,
+			LoudnessMaxTime: seconds(s.LoudnessMaxTime),
+			Pitches:         s.Pitches, Timbre: s.Timbre,
+		})
+	}
+	return res
+}
+
+// conv converts device to Device.
+func (d device) conv() Device {
+	res := Device{ID: d.ID, Name: d.Name, Type: d.Type, Active: d.Active,
+		Volume: -1}
+	if d.Volume != nil {
+		res.Volume = *d.Volume
+	}
+	return res
+}
@@ -3,6 +3,10 @@
 package spotify
 
 import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,22 +14,35 @@ import (
 )
 
 // Dbus is a structure implementing Dbus logic controlling Spotify
-// desktop application.
+// desktop application or any other media player implementing MPRIS.
 type Dbus struct {
 	sync.Mutex
-	o *dbs.Object // o is a dbus control object.
+	// Wait is a maximum time calls wait for the player if it is not
+	// available. Calls fail immediately if it is not positive.
+	Wait time.Duration
+
+	o     *dbs.Object // o is a dbus control object.
+	name  string      // name is a bus name of the player.
+	owner *nameOwner  // owner is an owner of the bus name.
+}
+
+func init() {
+	RegisterPlayer(PlayerDbus, func() (Player, error) { return NewDbus("") })
 }
 
-// NewDbus returns a new instance of Dbus.
-func NewDbus() (*Dbus, error) {
+// NewDbus returns a new instance of Dbus controlling MPRIS media player
+// with name, e.g. spotify, vlc or org.mpris.MediaPlayer2.vlc. If name is
+// empty, the first of MprisPlayers is controlled, or Spotify app if there
+// are no players.
+func NewDbus(name string) (*Dbus, error) {
 	d := &Dbus{}
-	if err := d.init(); err != nil {
+	if err := d.init(name); err != nil {
 		return nil, err
 	}
 	return d, nil
 }
 
-func (d *Dbus) init() error {
+func (d *Dbus) init(name string) error {
 	d.Lock()
 	defer d.Unlock()
 	if d.o != nil {
@@ -35,10 +52,226 @@ func (d *Dbus) init() error {
 	if err != nil {
 		return errorf("failed to init dbus session: %q", err)
 	}
-	d.o = c.Object(dest, objPath)
+	if name == "" {
+		name = spotifyPlayer
+		p, err := mprisPlayers(c)
+		if err != nil {
+			return err
+		}
+		if len(p) > 0 {
+			name = p[0].Name
+		}
+	}
+	d.name = busName(name)
+	if d.owner, err = watch(c, d.name); err != nil {
+		return err
+	}
+	d.o = c.Object(d.name, objPath)
+	return nil
+}
+
+// Available reports whether the player is running, i.e. it owns its bus
+// name. As changes of owner are delivered asynchronously, the owner is
+// queried if the player is not known to be available.
+func (d *Dbus) Available() bool {
+	dispatcher.Lock()
+	ok := d.owner.owner != ""
+	dispatcher.Unlock()
+	return ok || d.resolve()
+}
+
+// resolve queries the owner of the bus name of the player, updates it and
+// reports whether the name is owned. The owner is not updated if it was
+// changed by a signal in the meantime, as the signal is more recent.
+func (d *Dbus) resolve() bool {
+	c, err := dbs.SessionBus()
+	if err != nil {
+		return false
+	}
+	dispatcher.Lock()
+	prev := d.owner.owner
+	dispatcher.Unlock()
+	var owner string
+	if c.BusObject().Call(methodGetNameOwner, 0, d.name).Store(&owner) != nil {
+		owner = ""
+	}
+	dispatcher.Lock()
+	defer dispatcher.Unlock()
+	if d.owner.owner == prev {
+		d.owner.set(owner)
+	}
+	return d.owner.owner != ""
+}
+
+// WaitAvailable waits until the player is available or ctx is done. Owner of
+// the bus name of the player is also polled, as changes of owner may be
+// missed.
+func (d *Dbus) WaitAvailable(ctx context.Context) error {
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+	for !d.Available() {
+		dispatcher.Lock()
+		avail := d.owner.avail
+		dispatcher.Unlock()
+		select {
+		case <-avail:
+		case <-t.C:
+		case <-ctx.Done():
+			if d.resolve() {
+				return nil
+			}
+			return ctx.Err()
+		}
+	}
 	return nil
 }
 
+// ready returns an error if the player is not available. If d.Wait is
+// positive, it waits for the player at most d.Wait, so calls are queued
+// while the player restarts.
+func (d *Dbus) ready() error {
+	if d.Wait > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), d.Wait)
+		defer cancel()
+		if d.WaitAvailable(ctx) == nil {
+			return nil
+		}
+	} else if d.Available() {
+		return nil
+	}
+	return errorf("player %s is not available", d.Name())
+}
+
+// call calls method of the player if it is available.
+func (d *Dbus) call(method string, args ...interface{}) error {
+	return d.retry(false, func() error { return d.send(method, nil, args...) })
+}
+
+// prop returns value of property of the player if it is available.
+func (d *Dbus) prop(name string) (dbs.Variant, error) {
+	i := strings.LastIndex(name, ".")
+	var v dbs.Variant
+	err := d.retry(true, func() error {
+		return d.send(methodGet, []interface{}{&v}, name[:i], name[i+1:])
+	})
+	return v, err
+}
+
+// retry calls f if the player is available. If f fails as the player is
+// unreachable, the owner of its bus name is queried, as changes of owner
+// may be missed, and f is retried once if the player is available. Calls
+// which may have been delivered are retried only if f is idempotent.
+func (d *Dbus) retry(idempotent bool, f func() error) error {
+	if err := d.ready(); err != nil {
+		return err
+	}
+	err := f()
+	e, ok := err.(dbs.Error)
+	if !ok || (e.Name != errServiceUnknown && e.Name != errNoReply) {
+		return err
+	}
+	if d.resolve() && (idempotent || e.Name == errServiceUnknown) {
+		return f()
+	}
+	return err
+}
+
+// send calls method of the player and stores its reply in ret. It fails
+// with NoReply error if there is no reply within callTimeout.
+func (d *Dbus) send(method string, ret []interface{},
+	args ...interface{}) error {
+	c := d.o.Go(method, 0, make(chan *dbs.Call, 1), args...)
+	t := time.NewTimer(callTimeout)
+	defer t.Stop()
+	select {
+	case <-c.Done:
+		return c.Store(ret...)
+	case <-t.C:
+		return dbs.Error{Name: errNoReply, Body: []interface{}{
+			"no reply from " + d.Name()}}
+	}
+}
+
+// Name returns name of the controlled player, e.g. spotify.
+func (d *Dbus) Name() string {
+	return strings.TrimPrefix(d.name, mprisPrefix)
+}
+
+// MprisPlayer is a media player implementing MPRIS.
+type MprisPlayer struct {
+	Name     string // Name is a bus name of the player without MPRIS prefix.
+	Identity string // Identity is a human readable name of the player.
+	Status   Status // Status is a status of playback of the player.
+}
+
+// MprisPlayers returns media players available on the session bus, the most
+// recently active first. As MPRIS does not report activity times, players
+// which are playing are considered more recently active than paused ones,
+// followed by stopped ones. Spotify app precedes other players in the same
+// state.
+func MprisPlayers() ([]MprisPlayer, error) {
+	c, err := dbs.SessionBus()
+	if err != nil {
+		return nil, errorf("failed to init dbus session: %q", err)
+	}
+	return mprisPlayers(c)
+}
+
+func mprisPlayers(c *dbs.Conn) ([]MprisPlayer, error) {
+	var names []string
+	if err := c.BusObject().Call(methodListNames, 0).Store(&names); err != nil {
+		return nil, errorf("failed to list players: %q", err)
+	}
+	var res []MprisPlayer
+	for _, name := range names {
+		if !strings.HasPrefix(name, mprisPrefix) {
+			continue
+		}
+		o, p := c.Object(name, objPath), MprisPlayer{
+			Name: strings.TrimPrefix(name, mprisPrefix),
+		}
+		if v, err := o.GetProperty(propIdentity); err == nil {
+			p.Identity, _ = v.Value().(string)
+		}
+		if v, err := o.GetProperty(propPlaybackStatus); err == nil {
+			st, _ := v.Value().(string)
+			p.Status, _ = makeStatus(st)
+		}
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		ri, rj := activity(res[i]), activity(res[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// activity returns rank of p used to order players by recent activity.
+func activity(p MprisPlayer) int {
+	res := 4
+	switch p.Status {
+	case Playing:
+		res = 0
+	case Paused:
+		res = 2
+	}
+	if p.Name != spotifyPlayer {
+		res++
+	}
+	return res
+}
+
+// busName returns bus name of MPRIS player with name.
+func busName(name string) string {
+	if strings.HasPrefix(name, mprisPrefix) {
+		return name
+	}
+	return mprisPrefix + name
+}
+
 // Next plays next track.
 func (d *Dbus) Next() error {
 	return d.noArgsMethod(methodNext)
@@ -69,24 +302,42 @@ func (d *Dbus) Play() error {
 	return d.noArgsMethod(methodPlay)
 }
 
-// Goto seeks for offset µs.
+// Goto seeks for offset, MPRIS expects it in µs.
 func (d *Dbus) Goto(offset time.Duration) error {
-	return d.o.Call(methodSeek, 0, offset.Nanoseconds()*1000).Err
+	return d.call(methodSeek, offset.Microseconds())
 }
 
-// SetPos goes to specified positionk.
+// SetPos goes to specified position in the current track.
 func (d *Dbus) SetPos(pos time.Duration) error {
-	track, err := d.Track()
+	m, err := d.Metadata()
 	if err != nil {
 		return err
 	}
-	return d.o.Call(methodSetPos, 0, dbs.ObjectPath(string(track.URI)),
-		pos.Nanoseconds()*1000).Err
+	return d.call(methodSetPos, dbs.ObjectPath(m.TrackID), pos.Microseconds())
 }
 
-// Open starts playing track with URI.
+// Open starts playing item with URI. Spotify URIs can be given in any form
+// accepted by ParseURI, e.g. open.spotify.com URL. Other URIs, e.g. file
+// URIs, are passed to the player unchanged.
 func (d *Dbus) Open(uri URI) error {
-	return d.o.Call(methodOpenURI, 0, string(uri)).Err
+	u, err := openURI(string(uri))
+	if err != nil {
+		return err
+	}
+	return d.call(methodOpenURI, u)
+}
+
+// openURI returns canonical form of Spotify URI or URL s and any other URI
+// unchanged.
+func openURI(s string) (string, error) {
+	t := strings.TrimSpace(s)
+	u, err := url.Parse(t)
+	if err == nil && u.Scheme != "" && u.Scheme != uriScheme &&
+		u.Host != openHost {
+		return s, nil
+	}
+	uri, err := ParseURI(t)
+	return string(uri), err
 }
 
 // Quit quits Spotify app.
@@ -99,42 +350,98 @@ func (d *Dbus) Raise() error {
 	return d.noArgsMethod(methodRaise)
 }
 
-// Track returns currently played track.
-func (d *Dbus) Track() (track Track, err error) {
-	v, err := d.o.GetProperty(propMetadata)
+// Metadata is metadata of the current track reported by Spotify app.
+type Metadata struct {
+	// Track is the current track with its duration, artists and numbers.
+	Track
+	TrackID      string    // TrackID is an MPRIS object path of the track.
+	ArtURL       string    // ArtURL is an URL of the album cover.
+	AlbumArtists []string  // AlbumArtists are names of artists of the album.
+	AutoRating   float64   // AutoRating is popularity in range 0-1.
+	Created      time.Time // Created is a date the track was created.
+	Genres       []string  // Genres is a list of genres of the track.
+}
+
+// Metadata returns metadata of the current track.
+func (d *Dbus) Metadata() (Metadata, error) {
+	v, err := d.prop(propMetadata)
 	if err != nil {
-		return
+		return Metadata{}, err
 	}
+	return metadata(v)
+}
+
+// Track returns currently played track.
+func (d *Dbus) Track() (Track, error) {
+	m, err := d.Metadata()
+	return m.Track, err
+}
+
+// metadata converts value of Metadata property. Fields with unexpected
+// types are ignored, but either mpris:trackid or xesam:url is required.
+func metadata(v dbs.Variant) (Metadata, error) {
 	m, ok := v.Value().(map[string]dbs.Variant)
-	defer func() {
-		if !ok {
-			err = errorf(invDbusResp, v.Value())
-			return
-		}
-	}()
 	if !ok {
-		return
+		return Metadata{}, errorf(invDbusResp, v.Value())
 	}
-	if track.Name, ok = m["xesam:title"].Value().(string); !ok {
-		return
+	var res Metadata
+	if id, ok := m["mpris:trackid"].Value().(dbs.ObjectPath); ok {
+		res.TrackID = string(id)
+	} else {
+		res.TrackID, _ = m["mpris:trackid"].Value().(string)
 	}
-	if track.URI, ok = m["xesam:url"].Value().(string); !ok {
-		return
+	res.URI, _ = m["xesam:url"].Value().(string)
+	if res.TrackID == "" && res.URI == "" {
+		return Metadata{}, errorf("no track metadata")
 	}
-	if track.AlbumName, ok = m["xesam:album"].Value().(string); !ok {
-		return
+	res.Name, _ = m["xesam:title"].Value().(string)
+	res.AlbumName, _ = m["xesam:album"].Value().(string)
+	res.ArtURL, _ = m["mpris:artUrl"].Value().(string)
+	res.AlbumArtists, _ = m["xesam:albumArtist"].Value().([]string)
+	res.Genres, _ = m["xesam:genre"].Value().([]string)
+	res.AutoRating, _ = m["xesam:autoRating"].Value().(float64)
+	artists, _ := m["xesam:artist"].Value().([]string)
+	for _, a := range artists {
+		res.Artists = append(res.Artists, Artist{Name: a})
 	}
-	artists, ok := m["xesam:artist"].Value().([]string)
-	if ok = ok && len(artists) > 0; !ok {
-		return
+	switch l := m["mpris:length"].Value().(type) {
+	case int64:
+		res.Duration = time.Duration(l * 1000)
+	case uint64:
+		res.Duration = time.Duration(l * 1000)
 	}
-	track.Artists = append(track.Artists, Artist{Name: artists[0]})
-	return
+	res.TrackNumber = intValue(m["xesam:trackNumber"])
+	res.DiscNumber = intValue(m["xesam:discNumber"])
+	if c, ok := m["xesam:contentCreated"].Value().(string); ok {
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05",
+			"2006-01-02", "2006-01", "2006"} {
+			if t, err := time.Parse(layout, c); err == nil {
+				res.Created = t
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// intValue returns value of integer variant v or 0 if v is not an integer.
+func intValue(v dbs.Variant) int {
+	switch n := v.Value().(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case uint32:
+		return int(n)
+	case uint64:
+		return int(n)
+	}
+	return 0
 }
 
 // Status returns current status of an app.
 func (d *Dbus) Status() (Status, error) {
-	v, err := d.o.GetProperty(propPlaybackStatus)
+	v, err := d.prop(propPlaybackStatus)
 	if err != nil {
 		return Status(""), err
 	}
@@ -146,40 +453,116 @@ func (d *Dbus) Status() (Status, error) {
 }
 
 // Length returns length of current track.
-func (d *Dbus) Length() (l time.Duration, err error) {
-	v, err := d.o.GetProperty(propMetadata)
+func (d *Dbus) Length() (time.Duration, error) {
+	m, err := d.Metadata()
+	return m.Duration, err
+}
+
+// Pos returns current position.
+func (d *Dbus) Pos() (time.Duration, error) {
+	v, err := d.prop(propPos)
 	if err != nil {
-		return
+		return 0, err
 	}
-	m, ok := v.Value().(map[string]dbs.Variant)
-	defer func() {
-		if !ok {
-			err = errorf(invDbusResp, v.Value())
-			return
-		}
-	}()
+	pos, ok := v.Value().(int64)
 	if !ok {
-		return
+		return 0, errorf(invDbusResp, v.Value())
 	}
-	length, ok := m["mpris:length"].Value().(uint64)
-	if !ok {
-		return
+	return time.Duration(pos * 1000), nil
+}
+
+// Volume returns volume in range 0-1.
+func (d *Dbus) Volume() (float64, error) {
+	return d.floatOpt(propVolume)
+}
+
+// SetVolume sets volume in range 0-1.
+func (d *Dbus) SetVolume(v float64) error {
+	if v < 0 || v > 1 {
+		return errorf("invalid volume: %v", v)
 	}
-	l = time.Duration(length * 1000)
-	return
+	return d.setProp(propVolume, v)
 }
 
-// Pos returns current position.
-func (d *Dbus) Pos() (time.Duration, error) {
-	v, err := d.o.GetProperty(propPos)
+// Shuffle reports whether shuffle is on.
+func (d *Dbus) Shuffle() (bool, error) {
+	return d.boolOpt(propShuffle)
+}
+
+// SetShuffle turns shuffle on or off.
+func (d *Dbus) SetShuffle(on bool) error {
+	return d.setProp(propShuffle, on)
+}
+
+// Loop returns loop mode of playback.
+func (d *Dbus) Loop() (Loop, error) {
+	v, err := d.prop(propLoopStatus)
+	if err != nil {
+		return LoopNone, err
+	}
+	l, ok := v.Value().(string)
+	if !ok || !Loop(l).valid() {
+		return LoopNone, errorf(invDbusResp, v.Value())
+	}
+	return Loop(l), nil
+}
+
+// SetLoop sets loop mode of playback.
+func (d *Dbus) SetLoop(l Loop) error {
+	if !l.valid() {
+		return errorf("invalid loop mode: %q", l)
+	}
+	return d.setProp(propLoopStatus, string(l))
+}
+
+// Rate returns playback rate, 1 is a normal speed.
+func (d *Dbus) Rate() (float64, error) {
+	return d.floatOpt(propRate)
+}
+
+// MinRate returns minimum playback rate.
+func (d *Dbus) MinRate() (float64, error) {
+	return d.floatOpt(propMinRate)
+}
+
+// MaxRate returns maximum playback rate.
+func (d *Dbus) MaxRate() (float64, error) {
+	return d.floatOpt(propMaxRate)
+}
+
+// SetRate sets playback rate, which has to be within MinRate and MaxRate.
+func (d *Dbus) SetRate(rate float64) error {
+	min, err := d.MinRate()
+	if err != nil {
+		return err
+	}
+	max, err := d.MaxRate()
+	if err != nil {
+		return err
+	}
+	if rate <= 0 || rate < min || rate > max {
+		return errorf("invalid rate: %v, want %v-%v", rate, min, max)
+	}
+	return d.setProp(propRate, rate)
+}
+
+// floatOpt is a helper func retrieving value of float property.
+func (d *Dbus) floatOpt(prop string) (float64, error) {
+	v, err := d.prop(prop)
 	if err != nil {
 		return 0, err
 	}
-	pos, ok := v.Value().(int64)
+	res, ok := v.Value().(float64)
 	if !ok {
 		return 0, errorf(invDbusResp, v.Value())
 	}
-	return time.Duration(pos * 1000), nil
+	return res, nil
+}
+
+// setProp is a helper func setting value of property.
+func (d *Dbus) setProp(prop string, v interface{}) error {
+	i := strings.LastIndex(prop, ".")
+	return d.call(methodSet, prop[:i], prop[i+1:], dbs.MakeVariant(v))
 }
 
 // CanPlay returns info if you can play.
@@ -189,7 +572,7 @@ func (d *Dbus) CanPlay() (bool, error) {
 
 // boolOpt is a helper func retrieving value of boolean property.
 func (d *Dbus) boolOpt(prop string) (bool, error) {
-	v, err := d.o.GetProperty(prop)
+	v, err := d.prop(prop)
 	if err != nil {
 		return false, err
 	}
@@ -200,6 +583,16 @@ func (d *Dbus) boolOpt(prop string) (bool, error) {
 	return res, nil
 }
 
+// CanPause checks if pause is available.
+func (d *Dbus) CanPause() (bool, error) {
+	return d.boolOpt(propCanPause)
+}
+
+// CanSeek checks if seeking is available.
+func (d *Dbus) CanSeek() (bool, error) {
+	return d.boolOpt(propCanSeek)
+}
+
 // CanNext checks if next is available.
 func (d *Dbus) CanNext() (bool, error) {
 	return d.boolOpt(propCanGoNext)
@@ -218,14 +611,285 @@ func (d *Dbus) CanControl() (bool, error) {
 // noArgsMethod is a helper function initializing `*dbuser` and calling
 // a provided method.
 func (d *Dbus) noArgsMethod(method string) error {
-	return d.o.Call(method, 0).Err
+	return d.call(method)
+}
+
+// Subscription is a subscription to events of a player. Events are
+// delivered on C, which is closed when the subscription is closed or the
+// connection to the session bus is lost. Events are dropped if C is full.
+// Subscription survives restarts of the player.
+type Subscription struct {
+	C <-chan Event // C is a channel of events.
+
+	c     chan Event
+	o     *dbs.Object
+	owner *nameOwner // owner is an owner of the bus name of the player.
+	rules []string   // rules are match rules of the subscription.
+	once  sync.Once
+}
+
+// Close closes the subscription and channel C.
+func (s *Subscription) Close() error {
+	var err error
+	s.once.Do(func() {
+		dispatcher.Lock()
+		if _, ok := dispatcher.subs[s]; ok {
+			delete(dispatcher.subs, s)
+			close(s.c)
+		}
+		dispatcher.Unlock()
+		for _, rule := range s.rules {
+			if e := s.o.Call(methodRemoveMatch, 0, rule).Err; err == nil {
+				err = e
+			}
+		}
+	})
+	return err
+}
+
+// nameOwner is an owner of a bus name of a player.
+type nameOwner struct {
+	owner string        // owner is a unique bus name, empty if not owned.
+	avail chan struct{} // avail is closed when the name is owned.
+}
+
+// set sets owner of the name.
+func (n *nameOwner) set(owner string) {
+	switch {
+	case n.owner == "" && owner != "":
+		close(n.avail)
+	case n.owner != "" && owner == "":
+		n.avail = make(chan struct{})
+	}
+	n.owner = owner
+}
+
+// dispatcher dispatches signals received by the shared connection to the
+// session bus to subscriptions and tracks owners of bus names of players.
+var dispatcher = struct {
+	sync.Mutex
+	subs    map[*Subscription]struct{}
+	owners  map[string]*nameOwner
+	running bool
+}{subs: map[*Subscription]struct{}{}, owners: map[string]*nameOwner{}}
+
+// watch starts tracking owner of bus name and returns it. Bus is called
+// without dispatcher locked, so a slow bus does not stall dispatching of
+// signals.
+func watch(conn *dbs.Conn, name string) (*nameOwner, error) {
+	dispatcher.Lock()
+	if !dispatcher.running {
+		dispatcher.running = true
+		sig, own := make(chan *dbs.Signal, eventsBuf),
+			make(chan *dbs.Signal, eventsBuf)
+		conn.Signal(sig)
+		conn.Signal(own)
+		go dispatch(sig)
+		go trackOwners(own)
+	}
+	n, ok := dispatcher.owners[name]
+	if !ok {
+		n = &nameOwner{avail: make(chan struct{})}
+		dispatcher.owners[name] = n
+	}
+	dispatcher.Unlock()
+	if ok {
+		return n, nil
+	}
+	o := conn.BusObject()
+	if err := o.Call(methodAddMatch, 0, ownerRule(name)).Err; err != nil {
+		dispatcher.Lock()
+		if dispatcher.owners[name] == n {
+			delete(dispatcher.owners, name)
+		}
+		dispatcher.Unlock()
+		return nil, errorf("failed to watch %s: %q", name, err)
+	}
+	var owner string
+	if o.Call(methodGetNameOwner, 0, name).Store(&owner) == nil {
+		dispatcher.Lock()
+		if n.owner == "" {
+			n.set(owner)
+		}
+		dispatcher.Unlock()
+	}
+	return n, nil
+}
+
+// Subscribe subscribes to PropertiesChanged and Seeked signals of the
+// player and delivers them as events.
+func (d *Dbus) Subscribe() (*Subscription, error) {
+	conn, err := dbs.SessionBus()
+	if err != nil {
+		return nil, errorf("failed to init dbus session: %q", err)
+	}
+	o := conn.BusObject()
+	rules := matchRules(d.name)
+	for i, rule := range rules {
+		if err = o.Call(methodAddMatch, 0, rule).Err; err != nil {
+			for _, rule := range rules[:i] {
+				o.Call(methodRemoveMatch, 0, rule)
+			}
+			return nil, errorf("failed to subscribe: %q", err)
+		}
+	}
+	c := make(chan Event, eventsBuf)
+	s := &Subscription{C: c, c: c, o: o, owner: d.owner, rules: rules}
+	dispatcher.Lock()
+	defer dispatcher.Unlock()
+	dispatcher.subs[s] = struct{}{}
+	return s, nil
+}
+
+// dispatch sends events converted from signals received on sig to
+// subscriptions. Subscriptions are closed when sig is closed.
+func dispatch(sig <-chan *dbs.Signal) {
+	for v := range sig {
+		e := events(v)
+		if len(e) == 0 {
+			continue
+		}
+		dispatcher.Lock()
+		for s := range dispatcher.subs {
+			if s.owner.owner != v.Sender {
+				continue
+			}
+			for i := range e {
+				select {
+				case s.c <- e[i]:
+				default:
+				}
+			}
+		}
+		dispatcher.Unlock()
+	}
+	dispatcher.Lock()
+	for s := range dispatcher.subs {
+		close(s.c)
+	}
+	dispatcher.subs = map[*Subscription]struct{}{}
+	dispatcher.Unlock()
+}
+
+// trackOwners updates owners of bus names according to NameOwnerChanged
+// signals received on sig. It has its own channel, so changes of owner are
+// not dropped when signals of players fill the channel of dispatch. Owners
+// are reset when sig is closed.
+func trackOwners(sig <-chan *dbs.Signal) {
+	for v := range sig {
+		if v.Name == sigNameOwnerChanged {
+			changeOwner(v)
+		}
+	}
+	dispatcher.Lock()
+	for _, n := range dispatcher.owners {
+		n.set("")
+	}
+	dispatcher.owners = map[string]*nameOwner{}
+	dispatcher.running = false
+	dispatcher.Unlock()
+}
+
+// changeOwner updates owner of bus name according to NameOwnerChanged
+// signal s.
+func changeOwner(s *dbs.Signal) {
+	if len(s.Body) < 3 {
+		return
+	}
+	name, _ := s.Body[0].(string)
+	owner, ok := s.Body[2].(string)
+	dispatcher.Lock()
+	defer dispatcher.Unlock()
+	if n := dispatcher.owners[name]; n != nil && ok {
+		n.set(owner)
+	}
+}
+
+// events converts signal of a player to events. Unknown signals and
+// properties are ignored.
+func events(s *dbs.Signal) []Event {
+	if s.Path != objPath {
+		return nil
+	}
+	switch s.Name {
+	case sigSeeked:
+		if len(s.Body) > 0 {
+			if pos, ok := s.Body[0].(int64); ok {
+				return []Event{Seeked{time.Duration(pos * 1000)}}
+			}
+		}
+		return nil
+	case sigPropsChanged:
+	default:
+		return nil
+	}
+	if len(s.Body) < 2 || s.Body[0] != ifacePlayer {
+		return nil
+	}
+	props, ok := s.Body[1].(map[string]dbs.Variant)
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var res []Event
+	for _, name := range names {
+		if e := event(name, props[name]); e != nil {
+			res = append(res, e)
+		}
+	}
+	return res
 }
 
+// event converts changed property of player to event or returns nil if
+// the property is not supported or its value is invalid.
+func event(name string, v dbs.Variant) Event {
+	switch name {
+	case "Metadata":
+		if m, err := metadata(v); err == nil {
+			return TrackChanged{m.Track}
+		}
+	case "PlaybackStatus":
+		if s, ok := v.Value().(string); ok {
+			if st, err := makeStatus(s); err == nil {
+				return StatusChanged{st}
+			}
+		}
+	case "Volume":
+		if vol, ok := v.Value().(float64); ok {
+			return VolumeChanged{vol}
+		}
+	case "Shuffle":
+		if sh, ok := v.Value().(bool); ok {
+			return ShuffleChanged{sh}
+		}
+	case "LoopStatus":
+		if l, ok := v.Value().(string); ok && Loop(l).valid() {
+			return LoopChanged{Loop(l)}
+		}
+	}
+	return nil
+}
+
+// eventsBuf is a size of buffers of signals and events.
+const eventsBuf = 16
+
+// callTimeout is a maximum time calls wait for a reply of a player.
+var callTimeout = 25 * time.Second
+
+// pollInterval is an interval of polling owner of bus name of a player
+// while waiting for it.
+var pollInterval = time.Second
+
 // invDbusResp is a format of an error message for an invalid dbus response.
 const invDbusResp = "invalid dbus response: %v"
 
 const (
-	dest               = "org.mpris.MediaPlayer2.spotify"
+	mprisPrefix        = "org.mpris.MediaPlayer2."
+	spotifyPlayer      = "spotify"
 	objPath            = "/org/mpris/MediaPlayer2"
 	methodNext         = "org.mpris.MediaPlayer2.Player.Next"
 	methodPrev         = "org.mpris.MediaPlayer2.Player.Previous"
@@ -265,4 +929,38 @@ const (
 	methodIntrospect   = "org.freedesktop.DBus.Introspectable.Introspect"
 	methodPing         = "org.freedesktop.DBus.Peer.Ping"
 	methodMachineID    = "org.freedesktop.DBus.Peer.GetMachineId"
+	methodAddMatch     = "org.freedesktop.DBus.AddMatch"
+	methodRemoveMatch  = "org.freedesktop.DBus.RemoveMatch"
+	methodListNames    = "org.freedesktop.DBus.ListNames"
+	methodGetNameOwner = "org.freedesktop.DBus.GetNameOwner"
+	ifacePlayer        = "org.mpris.MediaPlayer2.Player"
+	sigPropsChanged    = "org.freedesktop.DBus.Properties.PropertiesChanged"
+	sigSeeked          = "org.mpris.MediaPlayer2.Player.Seeked"
+)
+
+// sigNameOwnerChanged is a name of signal of changed owner of bus name.
+const sigNameOwnerChanged = "org.freedesktop.DBus.NameOwnerChanged"
+
+// Errors of calls to players which are not reachable.
+const (
+	errServiceUnknown = "org.freedesktop.DBus.Error.ServiceUnknown"
+	errNoReply        = "org.freedesktop.DBus.Error.NoReply"
 )
+
+// ownerRule returns match rule of changes of owner of bus name.
+func ownerRule(name string) string {
+	return "type='signal',sender='org.freedesktop.DBus'," +
+		"interface='org.freedesktop.DBus',member='NameOwnerChanged',arg0='" +
+		name + "'"
+}
+
+// matchRules returns match rules of signals of player with bus name.
+func matchRules(name string) []string {
+	return []string{
+		"type='signal',sender='" + name + "',path='" + objPath +
+			"',interface='org.freedesktop.DBus.Properties'," +
+			"member='PropertiesChanged'",
+		"type='signal',sender='" + name + "',path='" + objPath +
+			"',interface='" + ifacePlayer + "',member='Seeked'",
+	}
+}
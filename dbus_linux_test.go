@@ -0,0 +1,504 @@
+// +build linux
+
+package spotify
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	dbs "github.com/pblaszczyk/go.spotify/Godeps/_workspace/src/github.com/guelfey/go.dbus"
+)
+
+// fakeProps are properties of player of fake Spotify app.
+type fakeProps struct {
+	sync.Mutex
+	m map[string]dbs.Variant
+}
+
+func (f *fakeProps) Get(iface, prop string) (dbs.Variant, *dbs.Error) {
+	f.Lock()
+	defer f.Unlock()
+	v, ok := f.m[prop]
+	if !ok {
+		return dbs.Variant{}, &dbs.Error{
+			Name: "org.freedesktop.DBus.Error.UnknownProperty",
+			Body: []interface{}{prop},
+		}
+	}
+	return v, nil
+}
+
+func (f *fakeProps) Set(iface, prop string, v dbs.Variant) *dbs.Error {
+	f.Lock()
+	defer f.Unlock()
+	f.m[prop] = v
+	return nil
+}
+
+func (f *fakeProps) get(prop string) interface{} {
+	f.Lock()
+	defer f.Unlock()
+	return f.m[prop].Value()
+}
+
+// fakeSpotify owns the name of Spotify app on the session bus and serves
+// props of its player.
+func fakeSpotify(t *testing.T, props map[string]dbs.Variant) (*dbs.Conn,
+	*fakeProps) {
+	return fakeMpris(t, spotifyPlayer, props)
+}
+
+// fakeMpris owns the name of MPRIS player on the session bus and serves its
+// props. It skips the test if there is no session bus or the name is already
+// owned. Connection is not closed, as closing private connections is racy in
+// go.dbus.
+func fakeMpris(t *testing.T, name string, props map[string]dbs.Variant) (
+	*dbs.Conn, *fakeProps) {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		t.Skip("no session bus")
+	}
+	conn, err := dbs.SessionBusPrivate()
+	if err == nil {
+		if err = conn.Auth(nil); err == nil {
+			err = conn.Hello()
+		}
+	}
+	if err != nil {
+		t.Skipf("failed to connect to session bus: %q", err)
+	}
+	r, err := conn.RequestName(busName(name), dbs.NameFlagDoNotQueue)
+	if err != nil || r != dbs.RequestNameReplyPrimaryOwner {
+		t.Skipf("failed to own %s: %v", busName(name), err)
+	}
+	t.Cleanup(func() { conn.ReleaseName(busName(name)) })
+	f := &fakeProps{m: props}
+	conn.Export(f, objPath, "org.freedesktop.DBus.Properties")
+	return conn, f
+}
+
+func metadataVariant() dbs.Variant {
+	return dbs.MakeVariant(map[string]dbs.Variant{
+		"xesam:title":  dbs.MakeVariant("Tribute"),
+		"xesam:url":    dbs.MakeVariant("spotify:track:6crBy2sODw2HS53xquM6us"),
+		"xesam:album":  dbs.MakeVariant("Tenacious D"),
+		"xesam:artist": dbs.MakeVariant([]string{"Tenacious D"}),
+	})
+}
+
+var testTrack = Track{
+	Name:      "Tribute",
+	URI:       "spotify:track:6crBy2sODw2HS53xquM6us",
+	AlbumName: "Tenacious D",
+	Artists:   []Artist{{Name: "Tenacious D"}},
+}
+
+func TestEvents(t *testing.T) {
+	t.Parallel()
+	changed := func(props map[string]dbs.Variant) *dbs.Signal {
+		return &dbs.Signal{Path: objPath, Name: sigPropsChanged,
+			Body: []interface{}{ifacePlayer, props, []string{}}}
+	}
+	cases := []struct {
+		s    *dbs.Signal
+		want []Event
+	}{
+		{
+			&dbs.Signal{Path: objPath, Name: sigSeeked,
+				Body: []interface{}{int64(1500000)}},
+			[]Event{Seeked{1500 * time.Millisecond}},
+		},
+		{
+			changed(map[string]dbs.Variant{
+				"PlaybackStatus": dbs.MakeVariant("Paused"),
+				"Metadata":       metadataVariant(),
+				"Volume":         dbs.MakeVariant(0.5),
+				"Shuffle":        dbs.MakeVariant(true),
+				"LoopStatus":     dbs.MakeVariant("Track"),
+				"CanGoNext":      dbs.MakeVariant(true),
+			}),
+			[]Event{
+				LoopChanged{LoopTrack},
+				TrackChanged{testTrack},
+				StatusChanged{Paused},
+				ShuffleChanged{true},
+				VolumeChanged{0.5},
+			},
+		},
+		{changed(map[string]dbs.Variant{"Volume": dbs.MakeVariant("loud")}), nil},
+		{changed(map[string]dbs.Variant{"LoopStatus": dbs.MakeVariant("All")}),
+			nil},
+		{&dbs.Signal{Path: "/other", Name: sigSeeked,
+			Body: []interface{}{int64(1)}}, nil},
+		{&dbs.Signal{Path: objPath, Name: sigPropsChanged,
+			Body: []interface{}{"org.mpris.MediaPlayer2", map[string]dbs.Variant{
+				"Volume": dbs.MakeVariant(0.5)}}}, nil},
+	}
+	for i, cas := range cases {
+		if got := events(cas.s); !reflect.DeepEqual(got, cas.want) {
+			t.Errorf("want events=%v; got %v (%d)", cas.want, got, i)
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	conn, _ := fakeSpotify(t, map[string]dbs.Variant{})
+	d, err := NewDbus(spotifyPlayer)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	s, err := d.Subscribe()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if err = conn.Emit(objPath, sigSeeked, int64(2000000)); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if err = conn.Emit(objPath, sigPropsChanged, ifacePlayer,
+		map[string]dbs.Variant{"PlaybackStatus": dbs.MakeVariant("Playing")},
+		[]string{}); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	for _, want := range []Event{Seeked{2 * time.Second}, StatusChanged{Playing}} {
+		select {
+		case e := <-s.C:
+			if !reflect.DeepEqual(e, want) {
+				t.Errorf("want event=%v; got %v", want, e)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %v", want)
+		}
+	}
+	if err = s.Close(); err != nil {
+		t.Errorf("want err=nil; got %q", err)
+	}
+	if err = s.Close(); err != nil {
+		t.Errorf("want err=nil on second Close; got %q", err)
+	}
+	select {
+	case _, ok := <-s.C:
+		if ok {
+			t.Error("want C closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("timed out waiting for C to close")
+	}
+}
+
+func TestDbusProps(t *testing.T) {
+	_, f := fakeSpotify(t, map[string]dbs.Variant{
+		"Volume":      dbs.MakeVariant(0.5),
+		"Shuffle":     dbs.MakeVariant(false),
+		"LoopStatus":  dbs.MakeVariant("None"),
+		"Rate":        dbs.MakeVariant(1.0),
+		"MinimumRate": dbs.MakeVariant(0.5),
+		"MaximumRate": dbs.MakeVariant(2.0),
+	})
+	d, err := NewDbus(spotifyPlayer)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	for i, fn := range []func() error{
+		func() error { return d.SetVolume(0.25) },
+		func() error { return d.SetShuffle(true) },
+		func() error { return d.SetLoop(LoopPlaylist) },
+		func() error { return d.SetRate(1.5) },
+	} {
+		if err := fn(); err != nil {
+			t.Fatalf("want err=nil; got %q (%d)", err, i)
+		}
+	}
+	if v, err := d.Volume(); err != nil || v != 0.25 {
+		t.Errorf("want volume=0.25; got %v, %v", v, err)
+	}
+	if s, err := d.Shuffle(); err != nil || !s {
+		t.Errorf("want shuffle=true; got %t, %v", s, err)
+	}
+	if l, err := d.Loop(); err != nil || l != LoopPlaylist {
+		t.Errorf("want loop=%q; got %q, %v", LoopPlaylist, l, err)
+	}
+	if r, err := d.Rate(); err != nil || r != 1.5 {
+		t.Errorf("want rate=1.5; got %v, %v", r, err)
+	}
+	for i, fn := range []func() error{
+		func() error { return d.SetVolume(1.1) },
+		func() error { return d.SetLoop("Forever") },
+		func() error { return d.SetRate(2.5) },
+		func() error { return d.SetRate(0.25) },
+	} {
+		if err := fn(); err == nil {
+			t.Errorf("want err!=nil (%d)", i)
+		}
+	}
+	if r := f.get("Rate"); r != 1.5 {
+		t.Errorf("want rate=1.5 after invalid changes; got %v", r)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	t.Parallel()
+	m, err := metadata(dbs.MakeVariant(map[string]dbs.Variant{
+		"mpris:trackid": dbs.MakeVariant(
+			dbs.ObjectPath("/com/spotify/track/6crBy2sODw2HS53xquM6us")),
+		"mpris:length":         dbs.MakeVariant(uint64(248053000)),
+		"mpris:artUrl":         dbs.MakeVariant("https://i.scdn.co/image/ab67"),
+		"xesam:title":          dbs.MakeVariant("Tribute"),
+		"xesam:url":            dbs.MakeVariant("spotify:track:6crBy2sODw2HS53xquM6us"),
+		"xesam:album":          dbs.MakeVariant("Tenacious D"),
+		"xesam:albumArtist":    dbs.MakeVariant([]string{"Tenacious D"}),
+		"xesam:artist":         dbs.MakeVariant([]string{"Jack Black", "Kyle Gass"}),
+		"xesam:trackNumber":    dbs.MakeVariant(int32(3)),
+		"xesam:discNumber":     dbs.MakeVariant(int32(1)),
+		"xesam:autoRating":     dbs.MakeVariant(0.72),
+		"xesam:contentCreated": dbs.MakeVariant("2001-09-25T00:00:00"),
+		"xesam:genre":          dbs.MakeVariant([]string{"comedy rock"}),
+	}))
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := Metadata{
+		Track: Track{
+			URI:         "spotify:track:6crBy2sODw2HS53xquM6us",
+			Name:        "Tribute",
+			AlbumName:   "Tenacious D",
+			Artists:     []Artist{{Name: "Jack Black"}, {Name: "Kyle Gass"}},
+			Duration:    248053 * time.Millisecond,
+			TrackNumber: 3,
+			DiscNumber:  1,
+		},
+		TrackID:      "/com/spotify/track/6crBy2sODw2HS53xquM6us",
+		ArtURL:       "https://i.scdn.co/image/ab67",
+		AlbumArtists: []string{"Tenacious D"},
+		AutoRating:   0.72,
+		Created:      time.Date(2001, 9, 25, 0, 0, 0, 0, time.UTC),
+		Genres:       []string{"comedy rock"},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("want metadata=%+v; got %+v", want, m)
+	}
+	for i, v := range []dbs.Variant{
+		dbs.MakeVariant(map[string]dbs.Variant{}),
+		dbs.MakeVariant("Tribute"),
+	} {
+		if _, err := metadata(v); err == nil {
+			t.Errorf("want err!=nil (%d)", i)
+		}
+	}
+}
+
+func TestOpenURI(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		uri   string
+		want  string
+		isnil bool
+	}{
+		{
+			uri:   "spotify:track:6crBy2sODw2HS53xquM6us",
+			want:  "spotify:track:6crBy2sODw2HS53xquM6us",
+			isnil: true,
+		},
+		{
+			uri:   "https://open.spotify.com/intl-de/album/1AckkxSo39144vOBrJ1GkS?si=x",
+			want:  "spotify:album:1AckkxSo39144vOBrJ1GkS",
+			isnil: true,
+		},
+		{
+			uri:   "file:///home/jb/Tribute.mp3",
+			want:  "file:///home/jb/Tribute.mp3",
+			isnil: true,
+		},
+		{
+			uri:   "https://example.com/stream.ogg",
+			want:  "https://example.com/stream.ogg",
+			isnil: true,
+		},
+		{
+			uri:   "spotify:track",
+			isnil: false,
+		},
+		{
+			uri:   "Tribute",
+			isnil: false,
+		},
+	}
+	for i, cas := range cases {
+		u, err := openURI(cas.uri)
+		if (err == nil) != cas.isnil {
+			t.Errorf("want (err=nil)=isnil; err: %v, isnil: %t (%d)",
+				err, cas.isnil, i)
+			continue
+		}
+		if u != cas.want {
+			t.Errorf("want u=cas.want; got %q=%q (%d)", u, cas.want, i)
+		}
+	}
+}
+
+func TestDbusTrack(t *testing.T) {
+	fakeSpotify(t, map[string]dbs.Variant{"Metadata": metadataVariant()})
+	d, err := NewDbus(spotifyPlayer)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	tr, err := d.Track()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !reflect.DeepEqual(tr, testTrack) {
+		t.Errorf("want track=%v; got %v", testTrack, tr)
+	}
+}
+
+func TestMprisPlayers(t *testing.T) {
+	fakeSpotify(t, map[string]dbs.Variant{
+		"Identity":       dbs.MakeVariant("Spotify"),
+		"PlaybackStatus": dbs.MakeVariant("Paused"),
+	})
+	fakeMpris(t, "vlc", map[string]dbs.Variant{
+		"Identity":       dbs.MakeVariant("VLC media player"),
+		"PlaybackStatus": dbs.MakeVariant("Playing"),
+	})
+	fakeMpris(t, "mpv", map[string]dbs.Variant{
+		"Identity":       dbs.MakeVariant("mpv Media Player"),
+		"PlaybackStatus": dbs.MakeVariant("Paused"),
+	})
+	fakeMpris(t, "chromium.instance42", map[string]dbs.Variant{
+		"PlaybackStatus": dbs.MakeVariant("Stopped"),
+	})
+	p, err := MprisPlayers()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := []MprisPlayer{
+		{"vlc", "VLC media player", Playing},
+		{"spotify", "Spotify", Paused},
+		{"mpv", "mpv Media Player", Paused},
+		{"chromium.instance42", "", Stopped},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("want players=%v; got %v", want, p)
+	}
+	for name, want := range map[string]string{
+		"":                           "vlc",
+		"mpv":                        "mpv",
+		"org.mpris.MediaPlayer2.mpv": "mpv",
+	} {
+		d, err := NewDbus(name)
+		if err != nil {
+			t.Fatalf("want err=nil; got %q (%q)", err, name)
+		}
+		if d.Name() != want {
+			t.Errorf("want name=%q; got %q (%q)", want, d.Name(), name)
+		}
+	}
+}
+
+func TestDbusOwnerChanged(t *testing.T) {
+	defer func(d time.Duration) { callTimeout = d }(callTimeout)
+	callTimeout = time.Second
+	conn, _ := fakeSpotify(t, map[string]dbs.Variant{
+		"Volume": dbs.MakeVariant(0.5),
+	})
+	d, err := NewDbus(spotifyPlayer)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !d.Available() {
+		t.Fatal("want player available")
+	}
+	s, err := d.Subscribe()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	defer s.Close()
+	conn.ReleaseName(busName(spotifyPlayer))
+	for deadline := time.Now().Add(5 * time.Second); d.Available(); {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for player to quit")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, err = d.Volume(); err == nil {
+		t.Error("want err!=nil for unavailable player")
+	}
+	dispatcher.Lock()
+	d.owner.set(":1.0")
+	dispatcher.Unlock()
+	if _, err = d.Volume(); err == nil {
+		t.Error("want err!=nil for player with missed change of owner")
+	}
+	if d.Available() {
+		t.Error("want owner resolved after failed call")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() { errc <- d.WaitAvailable(ctx) }()
+	conn, _ = fakeSpotify(t, map[string]dbs.Variant{
+		"Volume": dbs.MakeVariant(0.7),
+	})
+	if err = <-errc; err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if v, err := d.Volume(); err != nil || v != 0.7 {
+		t.Errorf("want volume=0.7 of restarted player; got %v, %v", v, err)
+	}
+	if err = conn.Emit(objPath, sigSeeked, int64(3000000)); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	select {
+	case e := <-s.C:
+		if want := (Seeked{3 * time.Second}); e != want {
+			t.Errorf("want event=%v; got %v", want, e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event of restarted player")
+	}
+	conn.ReleaseName(busName(spotifyPlayer))
+	for deadline := time.Now().Add(5 * time.Second); d.Available(); {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for player to quit")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	d.Wait = 5 * time.Second
+	time.AfterFunc(50*time.Millisecond, func() {
+		conn.RequestName(busName(spotifyPlayer), dbs.NameFlagDoNotQueue)
+	})
+	if v, err := d.Volume(); err != nil || v != 0.7 {
+		t.Errorf("want queued call to succeed; got %v, %v", v, err)
+	}
+}
+
+func TestDbusWaitAvailablePoll(t *testing.T) {
+	defer func(d time.Duration) { pollInterval = d }(pollInterval)
+	pollInterval = 50 * time.Millisecond
+	conn, _ := fakeMpris(t, "poll", map[string]dbs.Variant{})
+	d, err := NewDbus("poll")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	conn.ReleaseName(busName("poll"))
+	for deadline := time.Now().Add(5 * time.Second); d.Available(); {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for player to quit")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Detach owner from dispatcher, as if changes of owner were dropped.
+	dispatcher.Lock()
+	d.owner = &nameOwner{avail: make(chan struct{})}
+	dispatcher.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() { errc <- d.WaitAvailable(ctx) }()
+	fakeMpris(t, "poll", map[string]dbs.Variant{})
+	if err = <-errc; err != nil {
+		t.Errorf("want err=nil; got %q", err)
+	}
+}
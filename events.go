@@ -0,0 +1,47 @@
+package spotify
+
+import "time"
+
+// Event is an event emitted by a player. It is one of TrackChanged,
+// StatusChanged, VolumeChanged, Seeked, ShuffleChanged or LoopChanged.
+type Event interface {
+	event()
+}
+
+// TrackChanged is emitted when the current track changes.
+type TrackChanged struct {
+	Track Track // Track is the new current track.
+}
+
+// StatusChanged is emitted when status of playback changes.
+type StatusChanged struct {
+	Status Status // Status is the new status of playback.
+}
+
+// VolumeChanged is emitted when volume changes.
+type VolumeChanged struct {
+	Volume float64 // Volume is the new volume in range 0-1.
+}
+
+// Seeked is emitted when current position changes other than by playing,
+// e.g. by seeking or replaying the track.
+type Seeked struct {
+	Pos time.Duration // Pos is the new current position.
+}
+
+// ShuffleChanged is emitted when shuffle is turned on or off.
+type ShuffleChanged struct {
+	Shuffle bool // Shuffle reports whether shuffle is on.
+}
+
+// LoopChanged is emitted when loop mode changes.
+type LoopChanged struct {
+	Loop Loop // Loop is the new loop mode.
+}
+
+func (TrackChanged) event()   {}
+func (StatusChanged) event()  {}
+func (VolumeChanged) event()  {}
+func (Seeked) event()         {}
+func (ShuffleChanged) event() {}
+func (LoopChanged) event()    {}
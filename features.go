@@ -0,0 +1,169 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Modes of a key.
+const (
+	ModeMinor = 0
+	ModeMajor = 1
+)
+
+// strings used for requesting audio features and analysis
+const (
+	lookupFeatures    = "audio-features"
+	lookupAnalysis    = "audio-analysis"
+	featuresBatch     = 100
+	pitchClassesCount = 12
+)
+
+// pitchClasses are names of pitch classes in standard Pitch Class notation.
+var pitchClasses = [pitchClassesCount]string{
+	"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B",
+}
+
+// PitchClass returns name of pitch class pc, e.g. 5 -> F. Empty string is
+// returned for -1 (no key detected) and other invalid values.
+func PitchClass(pc int) string {
+	if pc < 0 || pc >= pitchClassesCount {
+		return ""
+	}
+	return pitchClasses[pc]
+}
+
+// KeyName returns name of key with pitch class pc and mode, e.g. 5, 1 ->
+// F major. Empty string is returned if key or mode is invalid.
+func KeyName(pc, mode int) string {
+	p := PitchClass(pc)
+	switch {
+	case p == "":
+		return ""
+	case mode == ModeMajor:
+		return p + " major"
+	case mode == ModeMinor:
+		return p + " minor"
+	}
+	return ""
+}
+
+// Camelot returns key with pitch class pc and mode in Camelot notation used
+// for harmonic mixing, e.g. 0, 1 (C major) -> 8B, 9, 0 (A minor) -> 8A.
+// Empty string is returned if key or mode is invalid.
+func Camelot(pc, mode int) string {
+	if PitchClass(pc) == "" {
+		return ""
+	}
+	switch mode {
+	case ModeMajor:
+		return fmt.Sprintf("%dB", (7*pc+7)%12+1)
+	case ModeMinor:
+		// Minor key shares number with its relative major key.
+		return fmt.Sprintf("%dA", (7*(pc+3)+7)%12+1)
+	}
+	return ""
+}
+
+// AudioFeatures are acoustic attributes of a track.
+type AudioFeatures struct {
+	URI              string        // URI is a Spotify URI of the track.
+	Duration         time.Duration // Duration is the length of the track.
+	Key              int           // Key is a pitch class, -1 if unknown.
+	Mode             int           // Mode is ModeMajor or ModeMinor.
+	Tempo            float64       // Tempo in beats per minute.
+	TimeSignature    int           // TimeSignature is a number of beats in bar.
+	Loudness         float64       // Loudness in decibels.
+	Acousticness     float64       // Acousticness in range 0-1.
+	Danceability     float64       // Danceability in range 0-1.
+	Energy           float64       // Energy in range 0-1.
+	Instrumentalness float64       // Instrumentalness in range 0-1.
+	Liveness         float64       // Liveness in range 0-1.
+	Speechiness      float64       // Speechiness in range 0-1.
+	Valence          float64       // Valence (positiveness) in range 0-1.
+}
+
+// KeyName returns name of key of the track, e.g. F major.
+func (f AudioFeatures) KeyName() string {
+	return KeyName(f.Key, f.Mode)
+}
+
+// Camelot returns key of the track in Camelot notation, e.g. 7B.
+func (f AudioFeatures) Camelot() string {
+	return Camelot(f.Key, f.Mode)
+}
+
+// AudioAnalysis is a detailed analysis of a track's structure and musical
+// content.
+type AudioAnalysis struct {
+	Duration      time.Duration // Duration is the length of the track.
+	Key           int           // Key is a pitch class, -1 if unknown.
+	Mode          int           // Mode is ModeMajor or ModeMinor.
+	Tempo         float64       // Tempo in beats per minute.
+	TimeSignature int           // TimeSignature is a number of beats in bar.
+	Loudness      float64       // Loudness in decibels.
+	Bars          []Interval    // Bars is a list of bars of the track.
+	Beats         []Interval    // Beats is a list of beats of the track.
+	Tatums        []Interval    // Tatums is a list of tatums of the track.
+	Sections      []Section     // Sections is a list of sections of the track.
+	Segments      []Segment     // Segments is a list of sound segments.
+}
+
+// Interval is a time interval of a track detected by analysis.
+type Interval struct {
+	Start      time.Duration // Start is an offset of the interval.
+	Duration   time.Duration // Duration is the length of the interval.
+	Confidence float64       // Confidence of the detection in range 0-1.
+}
+
+// Section is a large variation in rhythm or timbre of a track, e.g. chorus.
+type Section struct {
+	Interval
+	Key           int     // Key is a pitch class, -1 if unknown.
+	Mode          int     // Mode is ModeMajor or ModeMinor.
+	Tempo         float64 // Tempo in beats per minute.
+	TimeSignature int     // TimeSignature is a number of beats in bar.
+	Loudness      float64 // Loudness in decibels.
+}
+
+// Segment is a part of a track with roughly consistent sound.
+type Segment struct {
+	Interval
+	LoudnessStart   float64       // LoudnessStart is loudness at start in dB.
+	LoudnessMax     float64       // LoudnessMax is the peak loudness in dB.
+	LoudnessMaxTime time.Duration // LoudnessMaxTime is offset of the peak.
+	Pitches         []float64     // Pitches are strengths of pitch classes.
+	Timbre          []float64     // Timbre is a vector of timbre features.
+}
+
+// GetAudioFeatures returns audio features of track identified by uri.
+func (c *Client) GetAudioFeatures(ctx context.Context,
+	uri URI) (AudioFeatures, error) {
+	var resp audioFeatures
+	if err := c.lookup(ctx, lookupFeatures, KindTrack, uri,
+		&resp); err != nil {
+		return AudioFeatures{}, err
+	}
+	return resp.conv(), nil
+}
+
+// GetAudioFeaturesBatch returns audio features of tracks identified by uris.
+// Result is aligned with uris, features, which were not found, are left
+// zero valued.
+func (c *Client) GetAudioFeaturesBatch(ctx context.Context,
+	uris ...URI) ([]AudioFeatures, error) {
+	return lookupMulti(ctx, c, lookupFeatures, KindTrack, featuresBatch, uris,
+		func(f *audioFeatures) AudioFeatures { return f.conv() })
+}
+
+// GetAudioAnalysis returns audio analysis of track identified by uri.
+func (c *Client) GetAudioAnalysis(ctx context.Context,
+	uri URI) (*AudioAnalysis, error) {
+	var resp audioAnalysis
+	if err := c.lookup(ctx, lookupAnalysis, KindTrack, uri,
+		&resp); err != nil {
+		return nil, err
+	}
+	return resp.conv(), nil
+}
@@ -0,0 +1,112 @@
+package spotify
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestKeyNames(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		pc, mode      int
+		name, camelot string
+	}{
+		{0, ModeMajor, "C major", "8B"},
+		{9, ModeMinor, "A minor", "8A"},
+		{5, ModeMajor, "F major", "7B"},
+		{7, ModeMajor, "G major", "9B"},
+		{4, ModeMinor, "E minor", "9A"},
+		{6, ModeMajor, "F# major", "2B"},
+		{1, ModeMinor, "C# minor", "12A"},
+		{-1, ModeMajor, "", ""},
+		{12, ModeMajor, "", ""},
+		{0, 2, "", ""},
+	}
+	for i, cas := range cases {
+		if n := KeyName(cas.pc, cas.mode); n != cas.name {
+			t.Errorf("want name=%q; got %q (%d)", cas.name, n, i)
+		}
+		if c := Camelot(cas.pc, cas.mode); c != cas.camelot {
+			t.Errorf("want camelot=%q; got %q (%d)", cas.camelot, c, i)
+		}
+	}
+}
+
+func TestGetAudioFeatures(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "audio_features.json")}}
+	c := &Client{get: g}
+	f, err := c.GetAudioFeatures(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := AudioFeatures{
+		URI:      "spotify:track:6crBy2sODw2HS53xquM6us",
+		Duration: 248053 * time.Millisecond, Key: 5, Mode: ModeMajor,
+		Tempo: 151.04, TimeSignature: 4, Loudness: -6.13,
+		Acousticness: 0.0123, Danceability: 0.473, Energy: 0.764,
+		Liveness: 0.356, Speechiness: 0.0627, Valence: 0.651,
+	}
+	if f != want {
+		t.Errorf("want %+v; got %+v", want, f)
+	}
+	if n := f.KeyName(); n != "F major" {
+		t.Errorf("want key=%q; got %q", "F major", n)
+	}
+	if u := endPointURL + "audio-features/6crBy2sODw2HS53xquM6us"; g.u[0] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[0])
+	}
+}
+
+func TestGetAudioFeaturesBatch(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{
+		jsonData(t, "audio_features_multi.json"),
+	}}}
+	res, err := c.GetAudioFeaturesBatch(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us",
+		"spotify:track:0000000000000000000000",
+		"spotify:track:3USOTQdPtZlgVurwaqsdI5")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if len(res) != 3 {
+		t.Fatalf("want len(res)=3; got %d", len(res))
+	}
+	if res[0].Camelot() != "7B" || res[1].URI != "" ||
+		res[2].Camelot() != "8A" {
+		t.Errorf("invalid features: %+v", res)
+	}
+}
+
+func TestGetAudioAnalysis(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{jsonData(t, "audio_analysis.json")}}}
+	a, err := c.GetAudioAnalysis(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if a.Tempo != 151.04 || a.Key != 5 || a.Mode != ModeMajor ||
+		a.TimeSignature != 4 {
+		t.Errorf("invalid track analysis: %+v", a)
+	}
+	if len(a.Bars) != 2 || len(a.Beats) != 2 || len(a.Tatums) != 1 ||
+		len(a.Sections) != 1 || len(a.Segments) != 1 {
+		t.Fatalf("invalid number of intervals: %+v", a)
+	}
+	if b := a.Bars[1]; b.Start != 2100*time.Millisecond ||
+		b.Duration != 1590*time.Millisecond || b.Confidence != 0.8 {
+		t.Errorf("invalid bar: %+v", b)
+	}
+	if s := a.Sections[0]; s.Duration != 30250*time.Millisecond ||
+		s.Tempo != 150.2 {
+		t.Errorf("invalid section: %+v", s)
+	}
+	if s := a.Segments[0]; s.LoudnessMaxTime != 50*time.Millisecond ||
+		len(s.Pitches) != 12 || len(s.Timbre) != 12 {
+		t.Errorf("invalid segment: %+v", s)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func jsonData(t *testing.T, name string) string {
@@ -18,15 +19,57 @@ var searchArtistFixt = struct {
 	res [][]Artist
 	err error
 }{
-	[][]Artist{{
-		{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "Tenacious D"},
-		{"spotify:artist:5sgprVkYi5OjM4nxKI8ZWg", "Tenacious"},
-		{"spotify:artist:2Sf0QliiNtuNTJe51TgalE", "Young Tenacious"},
-		{"spotify:artist:6snWJ93BNH3JIbLGWczD1D", "BO, TENACIOUS BREED"},
-		{"spotify:artist:7mtIirvrKV5SUE90cPeUnR", "Tenacious Da Terrist"},
-	},
+	[][]Artist{
 		{
-			{"spotify:artist:4FUej2oub0ZMSAfSpVpc4H", "M.T.T.S. (Ty Bru, Medic, Tenacious)"},
+			{
+				URI:        "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh",
+				Name:       "Tenacious D",
+				Genres:     []string{},
+				Popularity: 49,
+				Images: []Image{
+					{"https://i.scdn.co/image/0844a31bbae5b8880325056593622b234ae0bfc2", 1000, 1335},
+					{"https://i.scdn.co/image/df904a4ba4d85831fe583a078e745bb1022c9c01", 640, 854},
+					{"https://i.scdn.co/image/19c87613e56bb2c927f07b87772b9b5b4b080cbf", 200, 267},
+					{"https://i.scdn.co/image/b930d63f52bfa140395d6717cad1f97355dcb7f4", 64, 85},
+				},
+			},
+			{
+				URI:    "spotify:artist:5sgprVkYi5OjM4nxKI8ZWg",
+				Name:   "Tenacious",
+				Genres: []string{},
+				Images: []Image{
+					{"https://i.scdn.co/image/94a4081eb4c7940f4f8d7419bf22f7605444fb98", 640, 640},
+					{"https://i.scdn.co/image/dbf75d10278aa82c185bbdbda6e365e9416f010b", 300, 300},
+					{"https://i.scdn.co/image/c3276f5a137f6c3d75ef0875bc3c7ce8f747bbf2", 64, 64},
+				},
+			},
+			{
+				URI:    "spotify:artist:2Sf0QliiNtuNTJe51TgalE",
+				Name:   "Young Tenacious",
+				Genres: []string{},
+			},
+			{
+				URI:    "spotify:artist:6snWJ93BNH3JIbLGWczD1D",
+				Name:   "BO, TENACIOUS BREED",
+				Genres: []string{},
+			},
+			{
+				URI:    "spotify:artist:7mtIirvrKV5SUE90cPeUnR",
+				Name:   "Tenacious Da Terrist",
+				Genres: []string{},
+				Images: []Image{
+					{"https://i.scdn.co/image/3101137d74b460e599cb9e65cc4ead749bec4ae1", 640, 640},
+					{"https://i.scdn.co/image/49aae30ea33ed6c68b33fc88cf881301428e30f3", 300, 300},
+					{"https://i.scdn.co/image/96c70f4e13d879a04ce79a57290264b08d9bdc64", 64, 64},
+				},
+			},
+		},
+		{
+			{
+				URI:    "spotify:artist:4FUej2oub0ZMSAfSpVpc4H",
+				Name:   "M.T.T.S. (Ty Bru, Medic, Tenacious)",
+				Genres: []string{},
+			},
 		},
 		[]Artist(nil),
 	}, errEOF,
@@ -37,43 +80,122 @@ var searchAlbumFixt = struct {
 	err error
 }{
 	[][]Album{
-		{{
-			"spotify:album:4LJbsUCNTcNNNHNiX6qES1", "POD",
-			[]Artist{
-				{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "Tenacious D"},
+		{
+			{
+				URI:  "spotify:album:4LJbsUCNTcNNNHNiX6qES1",
+				Name: "POD",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", Name: "Tenacious D"},
+				},
+				Label:      "Columbia",
+				Popularity: 15,
+				Genres:     []string{"comedy rock"},
+				Type:       "single",
+				UPC:        "888880050557",
+				Images: []Image{
+					{"https://i.scdn.co/image/09f7b9135d24d6c1d68167490ef5ae7ad2fe0bdc", 640, 632},
+					{"https://i.scdn.co/image/42a9e3e63fd224c11358f40480b1147a96c093d8", 300, 296},
+					{"https://i.scdn.co/image/0b271339d65191ae6296fe8a28f052ca8146b767", 64, 63},
+				},
+				ReleaseDate:          "2006-10-16",
+				ReleaseDatePrecision: "day",
 			},
-		},
 			{
-				"spotify:album:33LXyaRjDrMZILnvp1umPU", "Tenacious",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMUU", "Tenacious DR"},
+				URI:  "spotify:album:33LXyaRjDrMZILnvp1umPU",
+				Name: "Tenacious",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMUU", Name: "Tenacious DR"},
+				},
+				Label:      "Epic",
+				Popularity: 16,
+				Genres:     []string{},
+				Type:       "album",
+				UPC:        "888880050557",
+				Images: []Image{
+					{"https://i.scdn.co/image/3e77866345188f1b247b60a9eb410b6fd60f0911", 640, 640},
+					{"https://i.scdn.co/image/bf51fef0121397baa5d6fa50bec815f6c82a7699", 300, 300},
+					{"https://i.scdn.co/image/6216631b8e1a0974040d5f79d27298f3bc5b02a0", 64, 64},
 				},
+				ReleaseDate:          "2006-10-16",
+				ReleaseDatePrecision: "day",
 			},
 			{
-				"spotify:album:7mv1ciCld5Bp1y6TDGtjQY", "Tenacious D",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "Tenacious D"},
+				URI:  "spotify:album:7mv1ciCld5Bp1y6TDGtjQY",
+				Name: "Tenacious D",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", Name: "Tenacious D"},
 				},
+				Label:      "Columbia",
+				Popularity: 17,
+				Genres:     []string{"comedy rock"},
+				Type:       "album",
+				UPC:        "888880050557",
+				Images: []Image{
+					{"https://i.scdn.co/image/2535fc0a1ed203642bfc74e6420e86fb1129cd38", 640, 640},
+					{"https://i.scdn.co/image/dad8f09fb2cccceb050c254381576b3a92580018", 300, 300},
+					{"https://i.scdn.co/image/60f3b3525461acea4691ac624f015c181168a977", 64, 64},
+				},
+				ReleaseDate:          "2006-10-16",
+				ReleaseDatePrecision: "day",
 			},
 			{
-				"spotify:album:0zPvqiP3ZmCyYgXdupvdBi", "Tenacious D",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMUU", "Tenacious DR"},
+				URI:  "spotify:album:0zPvqiP3ZmCyYgXdupvdBi",
+				Name: "Tenacious D",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMUU", Name: "Tenacious DR"},
+				},
+				Label:      "Epic",
+				Popularity: 18,
+				Genres:     []string{},
+				Type:       "album",
+				UPC:        "888880050557",
+				Images: []Image{
+					{"https://i.scdn.co/image/68820054e93e3686cd69eb38b3811da7fbfc1d55", 640, 636},
+					{"https://i.scdn.co/image/7831df51d92f2f818d632787210e4f9648e8598d", 300, 298},
+					{"https://i.scdn.co/image/08065f9b085b4d1f2331a1a8bc28ad5c5124083d", 64, 64},
 				},
+				ReleaseDate:          "2006-10-16",
+				ReleaseDatePrecision: "day",
 			},
 			{
-				"spotify:album:6PjFFuDv6tnIlwyT33ugdj", "Best In Da State, Vol. 1",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "Tenacious D"},
+				URI:  "spotify:album:6PjFFuDv6tnIlwyT33ugdj",
+				Name: "Best In Da State, Vol. 1",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", Name: "Tenacious D"},
 				},
+				Label:      "Sony Music",
+				Popularity: 19,
+				Genres:     []string{"comedy rock"},
+				Type:       "album",
+				UPC:        "888880050557",
+				Images: []Image{
+					{"https://i.scdn.co/image/3101137d74b460e599cb9e65cc4ead749bec4ae1", 640, 640},
+					{"https://i.scdn.co/image/49aae30ea33ed6c68b33fc88cf881301428e30f3", 300, 300},
+					{"https://i.scdn.co/image/96c70f4e13d879a04ce79a57290264b08d9bdc64", 64, 64},
+				},
+				ReleaseDate:          "2006-10-16",
+				ReleaseDatePrecision: "day",
 			},
 		},
 		{
 			{
-				"spotify:album:4LJbsUCNTcNNNHNiX6qES1", "POD",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMUU", "Tenacious DR"},
+				URI:  "spotify:album:4LJbsUCNTcNNNHNiX6qES1",
+				Name: "POD",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMUU", Name: "Tenacious DR"},
+				},
+				Label:      "Epic",
+				Popularity: 7,
+				Genres:     []string{},
+				Type:       "single",
+				UPC:        "888880050557",
+				Images: []Image{
+					{"https://i.scdn.co/image/09f7b9135d24d6c1d68167490ef5ae7ad2fe0bdc", 640, 632},
+					{"https://i.scdn.co/image/42a9e3e63fd224c11358f40480b1147a96c093d8", 300, 296},
+					{"https://i.scdn.co/image/0b271339d65191ae6296fe8a28f052ca8146b767", 64, 63},
 				},
+				ReleaseDate:          "2006-10-16",
+				ReleaseDatePrecision: "day",
 			},
 		},
 		[]Album(nil),
@@ -87,26 +209,50 @@ var searchTrackFixt = struct {
 	[][]Track{
 		{
 			{
-				"spotify:track:6crBy2sODw2HS53xquM6us", "Tribute",
-				"spotify:album:1AckkxSo39144vOBrJ1GkS", "Tenacious D",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "Tenacious D"},
+				URI:       "spotify:track:6crBy2sODw2HS53xquM6us",
+				Name:      "Tribute",
+				AlbumURI:  "spotify:album:1AckkxSo39144vOBrJ1GkS",
+				AlbumName: "Tenacious D",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", Name: "Tenacious D"},
 				},
+				Duration:    248053 * time.Millisecond,
+				Popularity:  67,
+				Explicit:    true,
+				TrackNumber: 3,
+				DiscNumber:  1,
+				ISRC:        "USSM10108746",
 			},
 			{
-				"spotify:track:3USOTQdPtZlgVurwaqsdI5", "Fuck Her Gently",
-				"spotify:album:1AckkxSo39144vOBrJ1GkS", "Tenacious D",
-				[]Artist{
-					{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", "Tenacious D"},
+				URI:       "spotify:track:3USOTQdPtZlgVurwaqsdI5",
+				Name:      "Fuck Her Gently",
+				AlbumURI:  "spotify:album:1AckkxSo39144vOBrJ1GkS",
+				AlbumName: "Tenacious D",
+				Artists: []Artist{
+					{URI: "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh", Name: "Tenacious D"},
 				},
+				Duration:    123666 * time.Millisecond,
+				Popularity:  63,
+				Explicit:    true,
+				TrackNumber: 6,
+				DiscNumber:  1,
+				ISRC:        "USSM10108754",
 			},
 		},
 		{
-			{"spotify:track:7jxSwduTONaRn00SQQbInK", "White and Nerdy (Karaoke Version)",
-				"spotify:album:4zb12kro15pJa2YG1uEcmR", "Drew's Famous #1 Karaoke Hits: Sing Like Tenacious D, Flight of the Conchords, & Friends",
-				[]Artist{
-					{"spotify:artist:0GRelLAVHzwasg0Ja7gJUy", "The Karaoke Crew"},
+			{
+				URI:       "spotify:track:7jxSwduTONaRn00SQQbInK",
+				Name:      "White and Nerdy (Karaoke Version)",
+				AlbumURI:  "spotify:album:4zb12kro15pJa2YG1uEcmR",
+				AlbumName: "Drew's Famous #1 Karaoke Hits: Sing Like Tenacious D, Flight of the Conchords, & Friends",
+				Artists: []Artist{
+					{URI: "spotify:artist:0GRelLAVHzwasg0Ja7gJUy", Name: "The Karaoke Crew"},
 				},
+				Duration:    169567 * time.Millisecond,
+				Popularity:  1,
+				TrackNumber: 18,
+				DiscNumber:  1,
+				ISRC:        "USTXK1000343",
 			},
 		},
 		[]Track(nil),
@@ -0,0 +1,213 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// strings used for managing user's library
+const (
+	libraryURL         = endPointURL + "me/%s?ids=%s"
+	libraryContainsURL = endPointURL + "me/%s/contains?ids=%s"
+	savedURL           = endPointURL + "me/%s"
+	libraryBatch       = 50
+)
+
+// libraryEndpoints are endpoints of library for kinds of items.
+var libraryEndpoints = map[string]string{
+	KindTrack:   "tracks",
+	KindAlbum:   "albums",
+	KindShow:    "shows",
+	KindEpisode: "episodes",
+}
+
+// SavedTrack is a track saved in user's library.
+type SavedTrack struct {
+	AddedAt time.Time // AddedAt is the time the track was saved.
+	Track
+}
+
+// SavedAlbum is an album saved in user's library.
+type SavedAlbum struct {
+	AddedAt time.Time // AddedAt is the time the album was saved.
+	Album
+}
+
+// SavedShow is a show saved in user's library.
+type SavedShow struct {
+	AddedAt time.Time // AddedAt is the time the show was saved.
+	Show
+}
+
+// SavedTracks returns Pager over tracks saved in user's library, the most
+// recently saved first.
+func (c *Client) SavedTracks(ctx context.Context,
+	o PageOpts) *Pager[SavedTrack] {
+	return newPager(ctx, c.get, fmt.Sprintf(savedURL, "tracks"), o,
+		func(_ context.Context, b []byte) ([]SavedTrack, respHeader, error) {
+			var resp savedTrackPage
+			if err := unmarshal(b, &resp); err != nil {
+				return nil, respHeader{}, err
+			}
+			var res []SavedTrack
+			for _, i := range resp.Items {
+				if i.Track != nil {
+					res = append(res, SavedTrack{i.AddedAt, i.Track.conv()})
+				}
+			}
+			return res, resp.respHeader, nil
+		})
+}
+
+// SavedAlbums returns Pager over albums saved in user's library, the most
+// recently saved first.
+func (c *Client) SavedAlbums(ctx context.Context,
+	o PageOpts) *Pager[SavedAlbum] {
+	return newPager(ctx, c.get, fmt.Sprintf(savedURL, "albums"), o,
+		func(_ context.Context, b []byte) ([]SavedAlbum, respHeader, error) {
+			var resp savedAlbumPage
+			if err := unmarshal(b, &resp); err != nil {
+				return nil, respHeader{}, err
+			}
+			var res []SavedAlbum
+			for _, i := range resp.Items {
+				if i.Album != nil {
+					res = append(res, SavedAlbum{i.AddedAt, i.Album.conv()})
+				}
+			}
+			return res, resp.respHeader, nil
+		})
+}
+
+// SavedShows returns Pager over shows saved in user's library, the most
+// recently saved first.
+func (c *Client) SavedShows(ctx context.Context,
+	o PageOpts) *Pager[SavedShow] {
+	return newPager(ctx, c.get, fmt.Sprintf(savedURL, "shows"), o,
+		func(_ context.Context, b []byte) ([]SavedShow, respHeader, error) {
+			var resp savedShowPage
+			if err := unmarshal(b, &resp); err != nil {
+				return nil, respHeader{}, err
+			}
+			var res []SavedShow
+			for _, i := range resp.Items {
+				if s := shows([]*show{i.Show}).conv(); len(s) > 0 {
+					res = append(res, SavedShow{i.AddedAt, s[0]})
+				}
+			}
+			return res, resp.respHeader, nil
+		})
+}
+
+// SaveToLibrary saves tracks, albums, shows or episodes identified by uris
+// in user's library.
+func (c *Client) SaveToLibrary(ctx context.Context, uris ...URI) error {
+	return c.library(ctx, http.MethodPut, uris)
+}
+
+// RemoveFromLibrary removes tracks, albums, shows or episodes identified
+// by uris from user's library.
+func (c *Client) RemoveFromLibrary(ctx context.Context, uris ...URI) error {
+	return c.library(ctx, http.MethodDelete, uris)
+}
+
+// LibraryContains reports whether tracks, albums, shows or episodes
+// identified by uris are saved in user's library. Result is aligned with
+// uris.
+func (c *Client) LibraryContains(ctx context.Context,
+	uris ...URI) ([]bool, error) {
+	g, err := libraryGroups(uris)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bool, len(uris))
+	for _, k := range g.kinds() {
+		idx := g[k]
+		for i := 0; i < len(idx); i += libraryBatch {
+			b := idx[i:minInt(i+libraryBatch, len(idx))]
+			var resp []bool
+			if err = c.getJSON(ctx, fmt.Sprintf(libraryContainsURL,
+				libraryEndpoints[k], b.ids()), &resp); err != nil {
+				return nil, err
+			}
+			for j := range resp {
+				if j < len(b) {
+					res[b[j].i] = resp[j]
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// library sends request with method for all uris in chunks of 50 IDs.
+func (c *Client) library(ctx context.Context, method string,
+	uris []URI) error {
+	g, err := libraryGroups(uris)
+	if err != nil {
+		return err
+	}
+	for _, k := range g.kinds() {
+		idx := g[k]
+		for i := 0; i < len(idx); i += libraryBatch {
+			b := idx[i:minInt(i+libraryBatch, len(idx))]
+			if err = c.sendJSON(ctx, method, fmt.Sprintf(libraryURL,
+				libraryEndpoints[k], b.ids()), nil, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// libraryItem is an item of library request.
+type libraryItem struct {
+	i  int    // i is an index of the item's URI.
+	id string // id is an ID of the item.
+}
+
+// libraryItems is a list of library items of the same kind.
+type libraryItems []libraryItem
+
+// ids returns comma separated IDs of items.
+func (l libraryItems) ids() string {
+	res := make([]string, len(l))
+	for i := range l {
+		res[i] = l[i].id
+	}
+	return strings.Join(res, ",")
+}
+
+// groups are library items grouped by their kind.
+type groups map[string]libraryItems
+
+// kinds returns sorted kinds of g.
+func (g groups) kinds() []string {
+	var res []string
+	for k := range g {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// libraryGroups groups items identified by uris by their kind.
+func libraryGroups(uris []URI) (groups, error) {
+	g := groups{}
+	for i := range uris {
+		u, err := ParseURI(string(uris[i]))
+		if err != nil {
+			return nil, err
+		}
+		k := u.Kind()
+		if _, ok := libraryEndpoints[k]; !ok {
+			return nil, errorf("library: unsupported URI: %q", uris[i])
+		}
+		g[k] = append(g[k], libraryItem{i, u.ID()})
+	}
+	return g, nil
+}
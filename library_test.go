@@ -0,0 +1,80 @@
+package spotify
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSavedTracks(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "saved_tracks.json")}}
+	c := &Client{get: g}
+	res, err := c.SavedTracks(context.Background(), PageOpts{}).All()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := []SavedTrack{
+		{time.Date(2016, 2, 20, 18, 30, 0, 0, time.UTC),
+			searchTrackFixt.res[0][0]},
+		{time.Date(2016, 1, 2, 8, 0, 5, 0, time.UTC),
+			searchTrackFixt.res[0][1]},
+	}
+	if len(res) != len(want) {
+		t.Fatalf("want len(res)=%d; got %d", len(want), len(res))
+	}
+	for i := range want {
+		if !res[i].AddedAt.Equal(want[i].AddedAt) ||
+			!reflect.DeepEqual(res[i].Track, want[i].Track) {
+			t.Errorf("want %v; got %v (%d)", want[i], res[i], i)
+		}
+	}
+	if u := endPointURL + "me/tracks"; g.u[0] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[0])
+	}
+}
+
+func TestLibraryContains(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{`[true]`, `[false, true]`}}
+	c := &Client{get: g}
+	res, err := c.LibraryContains(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us",
+		"spotify:album:4LJbsUCNTcNNNHNiX6qES1",
+		"https://open.spotify.com/track/3USOTQdPtZlgVurwaqsdI5")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if want := []bool{false, true, true}; !reflect.DeepEqual(res, want) {
+		t.Errorf("want %v; got %v", want, res)
+	}
+	want := []string{
+		endPointURL + "me/albums/contains?ids=4LJbsUCNTcNNNHNiX6qES1",
+		endPointURL + "me/tracks/contains?ids=6crBy2sODw2HS53xquM6us," +
+			"3USOTQdPtZlgVurwaqsdI5",
+	}
+	if !reflect.DeepEqual(g.u, want) {
+		t.Errorf("want urls=%v; got %v", want, g.u)
+	}
+}
+
+func TestSaveToLibrary(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{"", ""}}
+	c := &Client{get: g}
+	uris := make([]URI, libraryBatch+1)
+	for i := range uris {
+		uris[i] = "spotify:track:6crBy2sODw2HS53xquM6us"
+	}
+	if err := c.SaveToLibrary(context.Background(), uris...); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if want := []string{"PUT", "PUT"}; !reflect.DeepEqual(g.m, want) {
+		t.Errorf("want methods=%v; got %v", want, g.m)
+	}
+	if err := c.RemoveFromLibrary(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh"); err == nil {
+		t.Error("want err!=nil for artist URI")
+	}
+}
@@ -0,0 +1,252 @@
+package spotify
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// authURL is an URL of Spotify Accounts service authorization endpoint.
+const authURL = "https://accounts.spotify.com/authorize"
+
+// Login performs authorization code flow with PKCE. It starts redirect server
+// listening on loopback interface, asks user to grant access and exchanges
+// obtained authorization code for a token. Zero values of AuthURL and
+// TokenURL mean default endpoints.
+type Login struct {
+	ClientID string        // ClientID is a client ID of registered application.
+	Scopes   []string      // Scopes is a list of requested scopes.
+	Addr     string        // Addr is a loopback address of redirect server.
+	AuthURL  string        // AuthURL is an URL of authorization endpoint.
+	TokenURL string        // TokenURL is an URL of token endpoint.
+	Timeout  time.Duration // Timeout is the time to wait for user's consent.
+
+	// Open is called with authorization URL, which has to be visited by user,
+	// e.g. by opening it in a web browser.
+	Open func(url string) error
+}
+
+// NewLogin returns Login for application identified by clientID requesting
+// provided scopes. Redirect server listens on 127.0.0.1:8888, so
+// http://127.0.0.1:8888/callback has to be registered as redirect URI
+// of the application.
+func NewLogin(clientID string, scopes ...string) *Login {
+	return &Login{
+		ClientID: clientID,
+		Scopes:   scopes,
+		Addr:     "127.0.0.1:8888",
+		AuthURL:  authURL,
+		TokenURL: tokenURL,
+		Timeout:  5 * time.Minute,
+	}
+}
+
+// callbackPath is a path of redirect URI handled by redirect server.
+const callbackPath = "/callback"
+
+// Run performs the login and returns obtained token.
+func (l *Login) Run() (*Token, error) {
+	if l.Open == nil {
+		return nil, errorf("login: Open is not set")
+	}
+	verifier, err := randString(64)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randString(16)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", l.Addr)
+	if err != nil {
+		return nil, errorf("failed to start redirect server: %q", err)
+	}
+	redirect := "http://" + ln.Addr().String() + callbackPath
+	res := make(chan callbackRes, 1)
+	srv := &http.Server{Handler: callback(state, res)}
+	go srv.Serve(ln)
+	defer srv.Close()
+	u := l.authCodeURL(redirect, state, challenge(verifier))
+	if err = l.Open(u); err != nil {
+		return nil, err
+	}
+	var r callbackRes
+	select {
+	case r = <-res:
+	case <-time.After(l.Timeout):
+		return nil, errorf("login: timed out waiting for authorization")
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	tu := l.TokenURL
+	if tu == "" {
+		tu = tokenURL
+	}
+	return requestToken(&http.Client{Timeout: timeout}, tu, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {r.code},
+		"redirect_uri":  {redirect},
+		"client_id":     {l.ClientID},
+		"code_verifier": {verifier},
+	}, "", "")
+}
+
+// authCodeURL returns URL of authorization endpoint for the login.
+func (l *Login) authCodeURL(redirect, state, challenge string) string {
+	v := url.Values{
+		"client_id":             {l.ClientID},
+		"response_type":         {"code"},
+		"redirect_uri":          {redirect},
+		"code_challenge_method": {"S256"},
+		"code_challenge":        {challenge},
+		"state":                 {state},
+	}
+	if len(l.Scopes) > 0 {
+		v.Set("scope", strings.Join(l.Scopes, " "))
+	}
+	au := l.AuthURL
+	if au == "" {
+		au = authURL
+	}
+	sep := "?"
+	if strings.Contains(au, "?") {
+		sep = "&"
+	}
+	return au + sep + v.Encode()
+}
+
+// callbackRes is a result of a request handled by redirect server.
+type callbackRes struct {
+	code string
+	err  error
+}
+
+// callback returns handler of redirect URI, which sends obtained
+// authorization code through res. Only the first valid request is handled.
+func callback(state string, res chan<- callbackRes) http.Handler {
+	var once sync.Once
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != callbackPath {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		}
+		cr := callbackRes{code: q.Get("code")}
+		if e := q.Get("error"); e != "" {
+			cr.err = errorf("login: authorization denied: %q", e)
+		} else if cr.code == "" {
+			cr.err = errorf("login: no authorization code")
+		}
+		once.Do(func() { res <- cr })
+		if cr.err != nil {
+			fmt.Fprintln(w, "Login failed, you can close this window.")
+			return
+		}
+		fmt.Fprintln(w, "Login succeeded, you can close this window.")
+	})
+}
+
+// randString returns URL safe string encoding n random bytes.
+func randString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errorf("failed to generate random data: %q", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// challenge returns S256 code challenge for PKCE verifier.
+func challenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// UserTokenSource is a TokenSource providing tokens of logged in user.
+// Access token is refreshed when it expires and the refreshed token is
+// persisted in TokenStore under the profile name. Zero value of TokenURL
+// means default token endpoint.
+type UserTokenSource struct {
+	ClientID string // ClientID is a client ID of registered application.
+	TokenURL string // TokenURL is an URL of token endpoint.
+
+	mu      sync.Mutex
+	tok     *Token
+	store   *TokenStore
+	profile string
+	c       *http.Client
+}
+
+// NewUserTokenSource returns UserTokenSource using token stored for profile.
+func NewUserTokenSource(clientID string, store *TokenStore,
+	profile string) (*UserTokenSource, error) {
+	tok, err := store.Load(profile)
+	if err != nil {
+		return nil, err
+	}
+	return &UserTokenSource{
+		ClientID: clientID,
+		TokenURL: tokenURL,
+		tok:      tok,
+		store:    store,
+		profile:  profile,
+		c:        &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Token implements TokenSource.
+func (u *UserTokenSource) Token() (*Token, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.tok.Valid() {
+		return u.tok, nil
+	}
+	if u.tok == nil || u.tok.RefreshToken == "" {
+		return nil, errorf("no refresh token for profile %q", u.profile)
+	}
+	tu := u.TokenURL
+	if tu == "" {
+		tu = tokenURL
+	}
+	tok, err := requestToken(u.c, tu, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {u.tok.RefreshToken},
+		"client_id":     {u.ClientID},
+	}, "", "")
+	if err != nil {
+		return nil, err
+	}
+	if tok.RefreshToken == "" {
+		tok.RefreshToken = u.tok.RefreshToken
+	}
+	if tok.Scope == "" {
+		tok.Scope = u.tok.Scope
+	}
+	u.tok = tok
+	if err = u.store.Save(u.profile, tok); err != nil {
+		return nil, err
+	}
+	return tok, nil
+}
+
+// invalidate implements invalidator. Refresh token is preserved.
+func (u *UserTokenSource) invalidate() {
+	u.mu.Lock()
+	if u.tok != nil {
+		tok := *u.tok
+		tok.Expiry = time.Time{}
+		u.tok = &tok
+	}
+	u.mu.Unlock()
+}
@@ -0,0 +1,189 @@
+package spotify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func tmpStore(t *testing.T) (*TokenStore, func()) {
+	dir, err := ioutil.TempDir("", "go.spotify")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	s, err := NewTokenStore(dir)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	return s, func() {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Logf("failed to remove %q: %q", dir, err)
+		}
+	}
+}
+
+func TestTokenStore(t *testing.T) {
+	t.Parallel()
+	s, td := tmpStore(t)
+	defer td()
+	if _, err := s.Load("default"); !IsNoToken(err) {
+		t.Errorf("want err=ErrNoToken; got %v", err)
+	}
+	tok := &Token{AccessToken: "a", RefreshToken: "r",
+		Expiry: time.Now().Round(time.Second)}
+	if err := s.Save("default", tok); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	fi, err := os.Stat(s.Dir + "/default.json")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if m := fi.Mode().Perm(); runtime.GOOS != "windows" && m != 0600 {
+		t.Errorf("want m=0600; got %o", m)
+	}
+	got, err := s.Load("default")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if got.AccessToken != tok.AccessToken ||
+		got.RefreshToken != tok.RefreshToken || !got.Expiry.Equal(tok.Expiry) {
+		t.Errorf("want got=tok; got %v=%v", got, tok)
+	}
+	if err = s.Delete("default"); err != nil {
+		t.Errorf("want err=nil; got %q", err)
+	}
+	if err = s.Delete("default"); !IsNoToken(err) {
+		t.Errorf("want err=ErrNoToken; got %v", err)
+	}
+	for _, p := range []string{"", "../x", ".hidden", `a\b`} {
+		if err = s.Save(p, tok); err == nil {
+			t.Errorf("want err!=nil for %q", p)
+		}
+	}
+}
+
+func pkceServer(t *testing.T, code string) *httptest.Server {
+	var chal string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if m := q.Get("code_challenge_method"); m != "S256" {
+			t.Errorf("want m=S256; got %q", m)
+		}
+		chal = q.Get("code_challenge")
+		u, err := url.Parse(q.Get("redirect_uri"))
+		if err != nil {
+			t.Errorf("want err=nil; got %q", err)
+			return
+		}
+		u.RawQuery = url.Values{
+			"code": {code}, "state": {q.Get("state")},
+		}.Encode()
+		http.Redirect(w, r, u.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("code") != "code" ||
+			challenge(r.PostForm.Get("code_verifier")) != chal {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"a","token_type":"Bearer",`+
+			`"refresh_token":"r","expires_in":3600}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestLogin(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		code  string
+		isnil bool
+	}{
+		{
+			code:  "code",
+			isnil: true,
+		},
+		{
+			code:  "invalid",
+			isnil: false,
+		},
+	}
+	for i, cas := range cases {
+		srv := pkceServer(t, cas.code)
+		l := NewLogin("id", "user-read-private")
+		l.Addr, l.AuthURL, l.TokenURL = "127.0.0.1:0", srv.URL+"/authorize",
+			srv.URL+"/token"
+		l.Open = func(u string) error {
+			go func() {
+				r, err := http.Get(u)
+				if err == nil {
+					r.Body.Close()
+				}
+			}()
+			return nil
+		}
+		tok, err := l.Run()
+		srv.Close()
+		if (err == nil) != cas.isnil {
+			t.Errorf("want (err=nil)=isnil; err: %v, isnil: %t (%d)",
+				err, cas.isnil, i)
+			continue
+		}
+		if err == nil && (tok.AccessToken != "a" || tok.RefreshToken != "r") {
+			t.Errorf("want tok={a r}; got %v (%d)", tok, i)
+		}
+	}
+}
+
+func TestLoginZeroValue(t *testing.T) {
+	t.Parallel()
+	l := &Login{ClientID: "id"}
+	u := l.authCodeURL("http://127.0.0.1/callback", "s", "c")
+	if !strings.HasPrefix(u, authURL+"?") {
+		t.Errorf("want u with prefix %q; got %q", authURL+"?", u)
+	}
+}
+
+func TestUserTokenSource(t *testing.T) {
+	t.Parallel()
+	s, td := tmpStore(t)
+	defer td()
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			if r.PostForm.Get("refresh_token") != "r" ||
+				r.PostForm.Get("client_id") != "id" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"access_token":"b","expires_in":3600}`)
+		}))
+	defer srv.Close()
+	if err := s.Save("p", &Token{AccessToken: "a", RefreshToken: "r"}); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	ts, err := NewUserTokenSource("id", s, "p")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	ts.TokenURL = srv.URL
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if tok.AccessToken != "b" || tok.RefreshToken != "r" {
+		t.Errorf("want tok={b r}; got %v", tok)
+	}
+	if tok, err = s.Load("p"); err != nil || tok.AccessToken != "b" {
+		t.Errorf("want stored token b; got %v, %v", tok, err)
+	}
+}
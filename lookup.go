@@ -0,0 +1,145 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Maximum numbers of IDs accepted by multiple items endpoints.
+const (
+	artistsBatch = 50
+	tracksBatch  = 50
+)
+
+// GetTrack returns track identified by uri.
+func (c *Client) GetTrack(ctx context.Context, uri URI) (Track, error) {
+	var resp trackData
+	if err := c.lookup(ctx, lookupTrack, KindTrack, uri, &resp); err != nil {
+		return Track{}, err
+	}
+	return resp.conv(), nil
+}
+
+// GetTracks returns tracks identified by uris. Result is aligned with uris,
+// tracks, which were not found, are left zero valued.
+func (c *Client) GetTracks(ctx context.Context, uris ...URI) ([]Track,
+	error) {
+	return lookupMulti(ctx, c, lookupTrack, KindTrack, tracksBatch, uris,
+		func(t *trackData) Track { return t.conv() })
+}
+
+// GetAlbum returns album identified by uri.
+func (c *Client) GetAlbum(ctx context.Context, uri URI) (Album, error) {
+	var resp albumFull
+	if err := c.lookup(ctx, lookupAlbum, KindAlbum, uri, &resp); err != nil {
+		return Album{}, err
+	}
+	return resp.conv(), nil
+}
+
+// GetAlbums returns albums identified by uris. Result is aligned with uris,
+// albums, which were not found, are left zero valued.
+func (c *Client) GetAlbums(ctx context.Context, uris ...URI) ([]Album,
+	error) {
+	return lookupMulti(ctx, c, lookupAlbum, KindAlbum, albumsBatch, uris,
+		func(a *albumFull) Album { return a.conv() })
+}
+
+// GetArtist returns artist identified by uri.
+func (c *Client) GetArtist(ctx context.Context, uri URI) (Artist, error) {
+	var resp artist
+	if err := c.lookup(ctx, lookupArtist, KindArtist, uri,
+		&resp); err != nil {
+		return Artist{}, err
+	}
+	return resp.conv(), nil
+}
+
+// GetArtists returns artists identified by uris. Result is aligned with
+// uris, artists, which were not found, are left zero valued.
+func (c *Client) GetArtists(ctx context.Context, uris ...URI) ([]Artist,
+	error) {
+	return lookupMulti(ctx, c, lookupArtist, KindArtist, artistsBatch, uris,
+		func(a *artist) Artist { return a.conv() })
+}
+
+// GetPlaylist returns playlist identified by uri.
+func (c *Client) GetPlaylist(ctx context.Context, uri URI) (Playlist,
+	error) {
+	var resp playlist
+	if err := c.lookup(ctx, lookupPlaylist, KindPlaylist, uri,
+		&resp); err != nil {
+		return Playlist{}, err
+	}
+	return playlists{&resp}.conv()[0], nil
+}
+
+// GetPlaylists returns playlists identified by uris. Web API has no multiple
+// playlists endpoint, so playlists are requested one by one.
+func (c *Client) GetPlaylists(ctx context.Context, uris ...URI) ([]Playlist,
+	error) {
+	res := make([]Playlist, len(uris))
+	for i := range uris {
+		p, err := c.GetPlaylist(ctx, uris[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = p
+	}
+	return res, nil
+}
+
+// lookup requests single item of kind identified by uri from endpoint and
+// stores decoded response in resp.
+func (c *Client) lookup(ctx context.Context, endpoint, kind string, uri URI,
+	resp interface{}) error {
+	id, err := lookupID(kind, uri)
+	if err != nil {
+		return err
+	}
+	return c.getJSON(ctx, fmt.Sprintf(lookupURL, endpoint, id), resp)
+}
+
+// lookupMulti requests items of kind identified by uris from multiple items
+// endpoint in batches of at most n IDs and converts them with f. Items are
+// expected in a response field named after endpoint, with dashes replaced
+// by underscores.
+func lookupMulti[J, T any](ctx context.Context, c *Client, endpoint,
+	kind string, n int, uris []URI, f func(*J) T) ([]T, error) {
+	ids := make([]string, len(uris))
+	for i := range uris {
+		id, err := lookupID(kind, uris[i])
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	res, key := make([]T, len(uris)), strings.ReplaceAll(endpoint, "-", "_")
+	for i := 0; i < len(ids); i += n {
+		b := ids[i:minInt(i+n, len(ids))]
+		var resp map[string][]*J
+		if err := c.getJSON(ctx, fmt.Sprintf(multiLookupURL, endpoint,
+			strings.Join(b, ",")), &resp); err != nil {
+			return nil, err
+		}
+		for j, item := range resp[key] {
+			if j < len(b) && item != nil {
+				res[i+j] = f(item)
+			}
+		}
+	}
+	return res, nil
+}
+
+// lookupID returns ID of uri, which has to be of kind.
+func lookupID(kind string, uri URI) (string, error) {
+	u, err := ParseURI(string(uri))
+	if err != nil {
+		return "", err
+	}
+	if u.Kind() != kind {
+		return "", errorf("want %s URI; got %q", kind, uri)
+	}
+	return u.ID(), nil
+}
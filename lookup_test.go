@@ -0,0 +1,124 @@
+package spotify
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetTrack(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "track.json")}}
+	c := &Client{get: g}
+	tr, err := c.GetTrack(context.Background(),
+		"https://open.spotify.com/track/6crBy2sODw2HS53xquM6us?si=x")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !reflect.DeepEqual(tr, searchTrackFixt.res[0][0]) {
+		t.Errorf("want %v; got %v", searchTrackFixt.res[0][0], tr)
+	}
+	if u := endPointURL + "tracks/6crBy2sODw2HS53xquM6us"; g.u[0] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[0])
+	}
+}
+
+func TestGetTracks(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "tracks.json")}}
+	c := &Client{get: g}
+	res, err := c.GetTracks(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us",
+		"spotify:track:0000000000000000000000",
+		"spotify:track:3USOTQdPtZlgVurwaqsdI5")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := []Track{searchTrackFixt.res[0][0], {}, searchTrackFixt.res[0][1]}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("want %v; got %v", want, res)
+	}
+	u := endPointURL + "tracks?ids=6crBy2sODw2HS53xquM6us," +
+		"0000000000000000000000,3USOTQdPtZlgVurwaqsdI5"
+	if g.u[0] != u {
+		t.Errorf("want url=%q; got %q", u, g.u[0])
+	}
+}
+
+func TestGetAlbum(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{jsonData(t, "album.json")}}}
+	a, err := c.GetAlbum(context.Background(),
+		"spotify:album:4LJbsUCNTcNNNHNiX6qES1")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !reflect.DeepEqual(a, searchAlbumFixt.res[0][0]) {
+		t.Errorf("want %v; got %v", searchAlbumFixt.res[0][0], a)
+	}
+}
+
+func TestGetArtist(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{jsonData(t, "artist.json")}}}
+	a, err := c.GetArtist(context.Background(),
+		"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !reflect.DeepEqual(a, searchArtistFixt.res[0][0]) {
+		t.Errorf("want %v; got %v", searchArtistFixt.res[0][0], a)
+	}
+}
+
+func TestGetArtistsBatches(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{`{"artists":[]}`, `{"artists":[]}`}}
+	c := &Client{get: g}
+	uris := make([]URI, artistsBatch+1)
+	for i := range uris {
+		uris[i] = "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh"
+	}
+	res, err := c.GetArtists(context.Background(), uris...)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if len(res) != len(uris) {
+		t.Errorf("want len(res)=%d; got %d", len(uris), len(res))
+	}
+	if len(g.u) != 2 {
+		t.Fatalf("want 2 requests; got %d", len(g.u))
+	}
+	if n := strings.Count(g.u[1], ","); n != 0 {
+		t.Errorf("want single ID in second request; got %q", g.u[1])
+	}
+}
+
+func TestGetPlaylist(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{jsonData(t, "playlist.json")}}}
+	p, err := c.GetPlaylist(context.Background(),
+		"spotify:user:spotify:playlist:37i9dQZF1DZ06evO1tcN2z")
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if p.URI != "spotify:playlist:37i9dQZF1DZ06evO1tcN2z" {
+		t.Errorf("want uri=%q; got %q", "spotify:playlist:37i9dQZF1DZ06evO1tcN2z",
+			p.URI)
+	}
+}
+
+func TestLookupError(t *testing.T) {
+	t.Parallel()
+	c := &Client{get: &getMock{d: []string{jsonData(t, "error_1.json")}}}
+	if _, err := c.GetAlbum(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us"); err == nil {
+		t.Error("want err!=nil for URI of different kind")
+	}
+	_, err := c.GetTrack(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us")
+	if _, ok := err.(webError); !ok {
+		t.Errorf("want err of type webError; got %T", err)
+	}
+}
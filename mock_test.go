@@ -1,23 +1,40 @@
 package spotify
 
 import (
+	"context"
 	"io"
 	"net/http"
+	"sync"
 )
 
 const testEnv = "SPOTIFY_APP_MOCK"
 
 type getMock struct {
+	sync.Mutex
 	d []string
 	i uint
+	u []string // u is a list of requested URLs.
+	m []string // m is a list of methods of requests sent with send.
+	b []string // b is a list of bodies of requests sent with send.
 }
 
-func (g *getMock) get(req string) (r *http.Response, err error) {
+func (g *getMock) get(_ context.Context, req string) (r *http.Response, err error) {
+	g.Lock()
+	defer g.Unlock()
 	r = &http.Response{Body: &rcMock{data: g.d[g.i]}}
+	g.u = append(g.u, req)
 	g.i++
 	return
 }
 
+func (g *getMock) send(ctx context.Context, method, req string,
+	body []byte) (*http.Response, error) {
+	g.Lock()
+	g.m, g.b = append(g.m, method), append(g.b, string(body))
+	g.Unlock()
+	return g.get(ctx, req)
+}
+
 type rcMock struct {
 	data   string
 	ready  bool
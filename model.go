@@ -3,22 +3,45 @@ package spotify
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
-// URI is a type representing Spotify URI.
-type URI string
-
 // Artist is a model for artist's data.
 type Artist struct {
-	URI  string // URI is a Spotify URI of the artist.
-	Name string // Name of the artist.
+	URI        string   // URI is a Spotify URI of the artist.
+	Name       string   // Name of the artist.
+	Genres     []string // Genres is a list of genres of the artist.
+	Popularity int      // Popularity of the artist in range 0-100.
+	Followers  int      // Followers is a number of followers of the artist.
+	Images     []Image  // Images of the artist, widest first.
+}
+
+// Image is a model for image's data.
+type Image struct {
+	URL    string // URL is a source URL of the image.
+	Width  int    // Width of the image in pixels, 0 if unknown.
+	Height int    // Height of the image in pixels, 0 if unknown.
 }
 
 // Album is a model for album's data.
 type Album struct {
-	URI     string   // URI is a Spotify URI of the album.
-	Name    string   // Name is the name of the album.
-	Artists []Artist // Artists is a list of artists of the album.
+	URI        string   // URI is a Spotify URI of the album.
+	Name       string   // Name is the name of the album.
+	Artists    []Artist // Artists is a list of artists of the album.
+	Label      string   // Label is the label which released the album.
+	Popularity int      // Popularity of the album in range 0-100.
+	Genres     []string // Genres is a list of genres of the album.
+	Type       string   // Type is album, single or compilation.
+	Tracks     int      // Tracks is a number of tracks of the album.
+	UPC        string   // UPC is a Universal Product Code of the album.
+	Images     []Image  // Images is a list of covers of the album, widest first.
+	Group      string   // Group is a relation to artist, set by ArtistAlbums.
+
+	// ReleaseDate is a date the album was released, e.g. 1981-12 with
+	// ReleaseDatePrecision set to month.
+	ReleaseDate string
+	// ReleaseDatePrecision is a precision of ReleaseDate: year, month or day.
+	ReleaseDatePrecision string
 }
 
 // Track is a model for track's data.
@@ -28,25 +51,92 @@ type Track struct {
 	AlbumURI  string   // AlbumURI is a URI of album containing track.
 	AlbumName string   // AlbumName is the name of album containing track.
 	Artists   []Artist // Artists is a list of artists of the track.
+
+	Duration    time.Duration // Duration is the length of the track.
+	Popularity  int           // Popularity of the track in range 0-100.
+	Explicit    bool          // Explicit reports explicit content.
+	TrackNumber int           // TrackNumber is a number of the track on its disc.
+	DiscNumber  int           // DiscNumber is a number of the disc of the track.
+	ISRC        string        // ISRC is an International Standard Recording Code.
+}
+
+// Playlist is a model for playlist's data.
+type Playlist struct {
+	URI         string // URI is a Spotify URI of the playlist.
+	Name        string // Name is the name of the playlist.
+	Description string // Description is a description of the playlist.
+	Owner       User   // Owner is the user who owns the playlist.
+	Public      bool   // Public reports whether the playlist is public.
+	Tracks      int    // Tracks is a number of items in the playlist.
+}
+
+// Show is a model for show's (podcast's) data.
+type Show struct {
+	URI         string // URI is a Spotify URI of the show.
+	Name        string // Name is the name of the show.
+	Publisher   string // Publisher is the name of the show's publisher.
+	Description string // Description is a description of the show.
+	Explicit    bool   // Explicit reports whether the show has explicit content.
+	Episodes    int    // Episodes is a number of episodes of the show.
+}
+
+// Episode is a model for show episode's data.
+type Episode struct {
+	URI         string        // URI is a Spotify URI of the episode.
+	Name        string        // Name is the name of the episode.
+	Description string        // Description is a description of the episode.
+	Duration    time.Duration // Duration is the length of the episode.
+	ReleaseDate string        // ReleaseDate is the date the episode was released.
+	Explicit    bool          // Explicit reports whether the episode has explicit content.
+}
+
+// Audiobook is a model for audiobook's data.
+type Audiobook struct {
+	URI       string   // URI is a Spotify URI of the audiobook.
+	Name      string   // Name is the name of the audiobook.
+	Authors   []string // Authors is a list of authors of the audiobook.
+	Narrators []string // Narrators is a list of narrators of the audiobook.
+	Publisher string   // Publisher is the name of the audiobook's publisher.
+	Chapters  int      // Chapters is a number of chapters of the audiobook.
 }
 
 // String implements `Stringer`.
 func (t Track) String() string {
 	trk := strings.Trim(t.Name, "\\\"")
 	alb := strings.Trim(t.AlbumName, "\\\"")
-	art := strings.Trim(t.Artists[0].Name, "\\\"")
+	names := make([]string, len(t.Artists))
+	for i := range t.Artists {
+		names[i] = t.Artists[i].Name
+	}
+	art := strings.Trim(strings.Join(names, ", "), "\\\"")
 	return fmt.Sprintf("Title:  %s\nAlbum:  %s\nArtist: %s", trk, alb, art)
 }
 
+// respHeader is a header of a paging object returned by Web API.
 type respHeader struct {
-	Total int     `json:"total"`
-	Next  *string `json:"next"`
+	Total   int      `json:"total"`
+	Limit   int      `json:"limit"`
+	Offset  int      `json:"offset"`
+	Next    *string  `json:"next"`
+	Cursors *Cursors `json:"cursors"`
 }
 
 type (
+	image struct {
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
+	}
+	images []image
 	artist struct {
-		URI  string `json:"uri"`
-		Name string `json:"name"`
+		URI        string   `json:"uri"`
+		Name       string   `json:"name"`
+		Genres     []string `json:"genres"`
+		Popularity int      `json:"popularity"`
+		Followers  struct {
+			Total int `json:"total"`
+		} `json:"followers"`
+		Images images `json:"images"`
 	}
 	artists    []artist
 	artistResp struct {
@@ -59,8 +149,14 @@ type (
 
 type (
 	album struct {
-		URI  string `json:"uri"`
-		Name string `json:"name"`
+		URI                  string `json:"uri"`
+		Name                 string `json:"name"`
+		AlbumType            string `json:"album_type"`
+		TotalTracks          int    `json:"total_tracks"`
+		ReleaseDate          string `json:"release_date"`
+		ReleaseDatePrecision string `json:"release_date_precision"`
+		Images               images `json:"images"`
+		AlbumGroup           string `json:"album_group"`
 	}
 	albums    []album
 	albumResp struct {
@@ -69,18 +165,37 @@ type (
 			respHeader
 		} `json:"albums"`
 	}
-	albumArtist struct {
-		Artists []struct {
-			URI  string `json:"uri"`
-			Name string `json:"name"`
-		} `json:"artists"`
+	albumFull struct {
+		Artists     artists  `json:"artists"`
+		Label       string   `json:"label"`
+		Popularity  int      `json:"popularity"`
+		Genres      []string `json:"genres"`
+		ExternalIDs struct {
+			UPC string `json:"upc"`
+		} `json:"external_ids"`
+		album
+	}
+	albumsResp struct {
+		Albums []*albumFull `json:"albums"`
+	}
+	albumPage struct {
+		Items []*albumFull `json:"items"`
+		respHeader
 	}
 )
 
 type (
 	track struct {
-		URI  string `json:"uri"`
-		Name string `json:"name"`
+		URI         string `json:"uri"`
+		Name        string `json:"name"`
+		DurationMs  int64  `json:"duration_ms"`
+		Popularity  int    `json:"popularity"`
+		Explicit    bool   `json:"explicit"`
+		TrackNumber int    `json:"track_number"`
+		DiscNumber  int    `json:"disc_number"`
+		ExternalIDs struct {
+			ISRC string `json:"isrc"`
+		} `json:"external_ids"`
 	}
 	trackData struct {
 		Album   album   `json:"album"`
@@ -88,6 +203,10 @@ type (
 		track
 	}
 	tracks    []trackData
+	trackPage struct {
+		Items []*trackData `json:"items"`
+		respHeader
+	}
 	trackResp struct {
 		Tracks struct {
 			Items tracks `json:"items"`
@@ -96,6 +215,218 @@ type (
 	}
 )
 
+type (
+	playlist struct {
+		URI         string   `json:"uri"`
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Public      bool     `json:"public"`
+		Owner       userResp `json:"owner"`
+		Tracks      struct {
+			Total int `json:"total"`
+		} `json:"tracks"`
+	}
+	playlists    []*playlist
+	playlistResp struct {
+		Playlists struct {
+			Items playlists `json:"items"`
+			respHeader
+		} `json:"playlists"`
+	}
+)
+
+type (
+	playlistDetails struct {
+		Name          string  `json:"name,omitempty"`
+		Description   *string `json:"description,omitempty"`
+		Public        *bool   `json:"public,omitempty"`
+		Collaborative *bool   `json:"collaborative,omitempty"`
+	}
+	playlistItemsReq struct {
+		URIs     []string `json:"uris"`
+		Position *int     `json:"position,omitempty"`
+	}
+	playlistTrack struct {
+		URI       string `json:"uri"`
+		Positions []int  `json:"positions,omitempty"`
+	}
+	playlistRemoveReq struct {
+		Tracks   []playlistTrack `json:"tracks"`
+		Snapshot string          `json:"snapshot_id,omitempty"`
+	}
+	playlistReorderReq struct {
+		Start    int    `json:"range_start"`
+		Length   int    `json:"range_length"`
+		Before   int    `json:"insert_before"`
+		Snapshot string `json:"snapshot_id,omitempty"`
+	}
+)
+
+type (
+	show struct {
+		URI           string `json:"uri"`
+		Name          string `json:"name"`
+		Publisher     string `json:"publisher"`
+		Description   string `json:"description"`
+		Explicit      bool   `json:"explicit"`
+		TotalEpisodes int    `json:"total_episodes"`
+	}
+	shows    []*show
+	showResp struct {
+		Shows struct {
+			Items shows `json:"items"`
+			respHeader
+		} `json:"shows"`
+	}
+)
+
+type (
+	episode struct {
+		URI         string `json:"uri"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		DurationMs  int64  `json:"duration_ms"`
+		ReleaseDate string `json:"release_date"`
+		Explicit    bool   `json:"explicit"`
+	}
+	episodes    []*episode
+	episodeResp struct {
+		Episodes struct {
+			Items episodes `json:"items"`
+			respHeader
+		} `json:"episodes"`
+	}
+)
+
+type (
+	person struct {
+		Name string `json:"name"`
+	}
+	audiobook struct {
+		URI           string   `json:"uri"`
+		Name          string   `json:"name"`
+		Authors       []person `json:"authors"`
+		Narrators     []person `json:"narrators"`
+		Publisher     string   `json:"publisher"`
+		TotalChapters int      `json:"total_chapters"`
+	}
+	audiobooks    []*audiobook
+	audiobookResp struct {
+		Audiobooks struct {
+			Items audiobooks `json:"items"`
+			respHeader
+		} `json:"audiobooks"`
+	}
+)
+
+type (
+	savedTrackPage struct {
+		Items []struct {
+			AddedAt time.Time  `json:"added_at"`
+			Track   *trackData `json:"track"`
+		} `json:"items"`
+		respHeader
+	}
+	savedAlbumPage struct {
+		Items []struct {
+			AddedAt time.Time  `json:"added_at"`
+			Album   *albumFull `json:"album"`
+		} `json:"items"`
+		respHeader
+	}
+	savedShowPage struct {
+		Items []struct {
+			AddedAt time.Time `json:"added_at"`
+			Show    *show     `json:"show"`
+		} `json:"items"`
+		respHeader
+	}
+)
+
+type (
+	device struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Type   string `json:"type"`
+		Active bool   `json:"is_active"`
+		Volume *int   `json:"volume_percent"`
+	}
+	playerState struct {
+		Device   device     `json:"device"`
+		Shuffle  bool       `json:"shuffle_state"`
+		Repeat   string     `json:"repeat_state"`
+		Progress int64      `json:"progress_ms"`
+		Playing  bool       `json:"is_playing"`
+		Item     *trackData `json:"item"`
+		Actions  struct {
+			Disallows map[string]bool `json:"disallows"`
+		} `json:"actions"`
+	}
+	playReq struct {
+		Context string   `json:"context_uri,omitempty"`
+		URIs    []string `json:"uris,omitempty"`
+	}
+	transferReq struct {
+		Devices []string `json:"device_ids"`
+		Play    bool     `json:"play"`
+	}
+)
+
+type (
+	audioFeatures struct {
+		URI              string  `json:"uri"`
+		DurationMs       int64   `json:"duration_ms"`
+		Key              int     `json:"key"`
+		Mode             int     `json:"mode"`
+		Tempo            float64 `json:"tempo"`
+		TimeSignature    int     `json:"time_signature"`
+		Loudness         float64 `json:"loudness"`
+		Acousticness     float64 `json:"acousticness"`
+		Danceability     float64 `json:"danceability"`
+		Energy           float64 `json:"energy"`
+		Instrumentalness float64 `json:"instrumentalness"`
+		Liveness         float64 `json:"liveness"`
+		Speechiness      float64 `json:"speechiness"`
+		Valence          float64 `json:"valence"`
+	}
+	interval struct {
+		Start      float64 `json:"start"`
+		Duration   float64 `json:"duration"`
+		Confidence float64 `json:"confidence"`
+	}
+	section struct {
+		interval
+		Key           int     `json:"key"`
+		Mode          int     `json:"mode"`
+		Tempo         float64 `json:"tempo"`
+		TimeSignature int     `json:"time_signature"`
+		Loudness      float64 `json:"loudness"`
+	}
+	segment struct {
+		interval
+		LoudnessStart   float64   `json:"loudness_start"`
+		LoudnessMax     float64   `json:"loudness_max"`
+		LoudnessMaxTime float64   `json:"loudness_max_time"`
+		Pitches         []float64 `json:"pitches"`
+		Timbre          []float64 `json:"timbre"`
+	}
+	audioAnalysis struct {
+		Track struct {
+			Duration      float64 `json:"duration"`
+			Key           int     `json:"key"`
+			Mode          int     `json:"mode"`
+			Tempo         float64 `json:"tempo"`
+			TimeSignature int     `json:"time_signature"`
+			Loudness      float64 `json:"loudness"`
+		} `json:"track"`
+		Bars     []interval `json:"bars"`
+		Beats    []interval `json:"beats"`
+		Tatums   []interval `json:"tatums"`
+		Sections []section  `json:"sections"`
+		Segments []segment  `json:"segments"`
+	}
+)
+
 type webError struct {
 	Err struct {
 		Status  int    `json:"status"`
@@ -137,3 +468,22 @@ func makeStatus(status string) (Status, error) {
 		return Status(""), fmt.Errorf("sscc: unsupported status: %s", status)
 	}
 }
+
+// User is a model for profile of Spotify user.
+type User struct {
+	ID      string // ID is a Spotify user ID.
+	URI     string // URI is a Spotify URI of the user.
+	Name    string // Name is a display name of the user.
+	Email   string // Email is an email address of the user.
+	Country string // Country is a country code of the user.
+	Product string // Product is a subscription level of the user.
+}
+
+type userResp struct {
+	ID          string `json:"id"`
+	URI         string `json:"uri"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Country     string `json:"country"`
+	Product     string `json:"product"`
+}
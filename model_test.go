@@ -0,0 +1,26 @@
+package spotify
+
+import "testing"
+
+func TestTrackString(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		t    Track
+		want string
+	}{
+		{
+			Track{Name: "Tribute", AlbumName: "Tenacious D",
+				Artists: []Artist{{Name: "Jack Black"}, {Name: "Kyle Gass"}}},
+			"Title:  Tribute\nAlbum:  Tenacious D\nArtist: Jack Black, Kyle Gass",
+		},
+		{
+			Track{Name: "Tribute"},
+			"Title:  Tribute\nAlbum:  \nArtist: ",
+		},
+	}
+	for i, cas := range cases {
+		if s := cas.t.String(); s != cas.want {
+			t.Errorf("want %q; got %q (%d)", cas.want, s, i)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package spotify
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageOpts configures paging of Web API results. Zero value requests
+// default page size starting from the first item.
+type PageOpts struct {
+	Limit  int    // Limit is a maximum number of items in a single page.
+	Offset int    // Offset is an index of the first item (offset-based paging).
+	After  string // After is a cursor of items to return (cursor-based paging).
+	Before string // Before is a cursor of items to return (cursor-based paging).
+	Max    int    // Max is a maximum number of returned items, 0 means no limit.
+}
+
+// values returns query parameters for o.
+func (o PageOpts) values() url.Values {
+	v := url.Values{}
+	if o.Limit > 0 {
+		v.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		v.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.After != "" {
+		v.Set("after", o.After)
+	}
+	if o.Before != "" {
+		v.Set("before", o.Before)
+	}
+	return v
+}
+
+// Cursors are cursors of a page returned by cursor-based paging endpoint.
+type Cursors struct {
+	After  string `json:"after"`  // After is a cursor of the next page.
+	Before string `json:"before"` // Before is a cursor of the previous page.
+}
+
+// decoder decodes body of a page response into items and page header.
+type decoder[T any] func(ctx context.Context, body []byte) ([]T, respHeader,
+	error)
+
+// Pager iterates over pages of paginated Web API result. Pages are fetched
+// lazily on calls to Next:
+//
+//	for p.Next() {
+//		use(p.Page())
+//	}
+//	if err := p.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	ctx     context.Context
+	get     geter
+	decode  decoder[T]
+	next    string // next is an URL of the next page, empty if there is none.
+	body    []byte // body is a prefetched first page, if any.
+	max     int
+	off     int // off is an offset of the next page.
+	n       int // n is a number of items returned so far.
+	page    []T
+	total   int
+	cursors Cursors
+	err     error
+}
+
+// newPager returns Pager starting at u with query parameters set by o.
+func newPager[T any](ctx context.Context, g geter, u string, o PageOpts,
+	d decoder[T]) *Pager[T] {
+	if v := o.values().Encode(); v != "" {
+		if strings.Contains(u, "?") {
+			u += "&" + v
+		} else {
+			u += "?" + v
+		}
+	}
+	return &Pager[T]{
+		ctx:    ctx,
+		get:    g,
+		decode: d,
+		next:   u,
+		max:    o.Max,
+		off:    o.Offset,
+	}
+}
+
+// prefetched returns Pager starting with the first page already obtained
+// in body. Subsequent pages are fetched from URLs returned by Web API.
+func prefetched[T any](ctx context.Context, g geter, body []byte, o PageOpts,
+	d decoder[T]) *Pager[T] {
+	return &Pager[T]{
+		ctx:    ctx,
+		get:    g,
+		decode: d,
+		body:   body,
+		max:    o.Max,
+		off:    o.Offset,
+	}
+}
+
+// Next fetches the next page. It returns false when there are no more pages,
+// the maximum number of items was reached or an error occurred. Empty pages
+// followed by other pages are skipped.
+func (p *Pager[T]) Next() bool {
+	if p.err != nil || (p.next == "" && p.body == nil) ||
+		(p.max > 0 && p.n >= p.max) {
+		return false
+	}
+	for {
+		if p.err = p.ctx.Err(); p.err != nil {
+			return false
+		}
+		items, h, err := p.fetch(p.next)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.next, p.total = "", h.Total
+		if h.Next != nil {
+			p.next = *h.Next
+		}
+		if h.Cursors != nil {
+			p.cursors = *h.Cursors
+		}
+		if p.max > 0 && p.n+len(items) > p.max {
+			items = items[:p.max-p.n]
+		}
+		p.off += len(items)
+		p.n += len(items)
+		p.page = items
+		if len(items) > 0 || p.next == "" {
+			return len(items) > 0
+		}
+	}
+}
+
+// fetch reads and decodes page available at u or the prefetched page.
+func (p *Pager[T]) fetch(u string) ([]T, respHeader, error) {
+	if b := p.body; b != nil {
+		p.body = nil
+		return p.decode(p.ctx, b)
+	}
+	r, err := p.get.get(p.ctx, u)
+	if err != nil {
+		return nil, respHeader{}, err
+	}
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, respHeader{}, err
+	}
+	return p.decode(p.ctx, body)
+}
+
+// Page returns items of the current page.
+func (p *Pager[T]) Page() []T {
+	return p.page
+}
+
+// Total returns a total number of items available, as reported by Web API.
+func (p *Pager[T]) Total() int {
+	return p.total
+}
+
+// Cursors returns cursors of the current page of cursor-based paging result.
+func (p *Pager[T]) Cursors() Cursors {
+	return p.cursors
+}
+
+// Err returns the error, which stopped iteration.
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All fetches all remaining pages and returns their items.
+func (p *Pager[T]) All() ([]T, error) {
+	var res []T
+	for p.Next() {
+		res = append(res, p.Page()...)
+	}
+	return res, p.Err()
+}
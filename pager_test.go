@@ -0,0 +1,141 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func cursorPages() []string {
+	return []string{
+		`{"items":[{"uri":"a","name":"A"},{"uri":"b","name":"B"}],"total":5,` +
+			`"next":"https://api.spotify.com/v1/x?after=b",` +
+			`"cursors":{"after":"b"}}`,
+		`{"items":[{"uri":"c","name":"C"},{"uri":"d","name":"D"}],"total":5,` +
+			`"next":"https://api.spotify.com/v1/x?after=d",` +
+			`"cursors":{"after":"d"}}`,
+		`{"items":[{"uri":"e","name":"E"}],"total":5,"next":null,` +
+			`"cursors":{"after":null}}`,
+	}
+}
+
+func testPager(g geter, o PageOpts) *Pager[Artist] {
+	return newPager(context.Background(), g, "https://api.spotify.com/v1/x", o,
+		func(_ context.Context, b []byte) ([]Artist, respHeader, error) {
+			var resp struct {
+				Items artists `json:"items"`
+				respHeader
+			}
+			if err := json.Unmarshal(b, &resp); err != nil {
+				return nil, respHeader{}, err
+			}
+			var res []Artist
+			for _, a := range resp.Items {
+				res = append(res, Artist{URI: a.URI, Name: a.Name})
+			}
+			return res, resp.respHeader, nil
+		})
+}
+
+func TestPager(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		opts    PageOpts
+		uris    [][]string
+		after   string
+		first   string
+		fetches int
+	}{
+		{
+			opts:    PageOpts{Limit: 2},
+			uris:    [][]string{{"a", "b"}, {"c", "d"}, {"e"}},
+			after:   "",
+			first:   "https://api.spotify.com/v1/x?limit=2",
+			fetches: 3,
+		},
+		{
+			opts:    PageOpts{Limit: 2, After: "z", Max: 3},
+			uris:    [][]string{{"a", "b"}, {"c"}},
+			after:   "d",
+			first:   "https://api.spotify.com/v1/x?after=z&limit=2",
+			fetches: 2,
+		},
+		{
+			opts:    PageOpts{Offset: 4, Max: 2},
+			uris:    [][]string{{"a", "b"}},
+			after:   "b",
+			first:   "https://api.spotify.com/v1/x?offset=4",
+			fetches: 1,
+		},
+	}
+	for i, cas := range cases {
+		g := &getMock{d: cursorPages()}
+		p := testPager(g, cas.opts)
+		var uris [][]string
+		for p.Next() {
+			var u []string
+			for _, a := range p.Page() {
+				u = append(u, a.URI)
+			}
+			uris = append(uris, u)
+		}
+		if err := p.Err(); err != nil {
+			t.Errorf("want err=nil; got %q (%d)", err, i)
+		}
+		if !reflect.DeepEqual(uris, cas.uris) {
+			t.Errorf("want uris=cas.uris; got %v=%v (%d)", uris, cas.uris, i)
+		}
+		if p.Total() != 5 {
+			t.Errorf("want p.Total()=5; got %d (%d)", p.Total(), i)
+		}
+		if c := p.Cursors().After; c != cas.after {
+			t.Errorf("want c=cas.after; got %q=%q (%d)", c, cas.after, i)
+		}
+		if len(g.u) != cas.fetches || g.u[0] != cas.first {
+			t.Errorf("want %d fetches starting at %q; got %v (%d)",
+				cas.fetches, cas.first, g.u, i)
+		}
+		if len(g.u) > 1 && !strings.HasSuffix(g.u[1], "after=b") {
+			t.Errorf("want next page URL followed; got %q (%d)", g.u[1], i)
+		}
+	}
+}
+
+func TestPagerEmptyPage(t *testing.T) {
+	t.Parallel()
+	d := cursorPages()
+	d[1] = `{"items":[],"total":5,` +
+		`"next":"https://api.spotify.com/v1/x?after=d",` +
+		`"cursors":{"after":"d"}}`
+	g := &getMock{d: d}
+	res, err := testPager(g, PageOpts{}).All()
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	var uris []string
+	for _, a := range res {
+		uris = append(uris, a.URI)
+	}
+	if want := []string{"a", "b", "e"}; !reflect.DeepEqual(uris, want) {
+		t.Errorf("want uris=%v; got %v", want, uris)
+	}
+	if len(g.u) != 3 {
+		t.Errorf("want 3 fetches; got %v", g.u)
+	}
+}
+
+func TestPagerError(t *testing.T) {
+	t.Parallel()
+	p := testPager(&getMock{d: []string{"{"}}, PageOpts{})
+	if p.Next() {
+		t.Error("want p.Next()=false")
+	}
+	if p.Err() == nil {
+		t.Error("want p.Err()!=nil")
+	}
+	if res, err := p.All(); res != nil || err == nil {
+		t.Errorf("want (nil, err); got (%v, %v)", res, err)
+	}
+}
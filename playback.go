@@ -0,0 +1,30 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// queueURL is an URL of endpoint adding item to the playback queue.
+const queueURL = endPointURL + "me/player/queue?uri="
+
+// Queue adds track or episode identified by uri to the end of the playback
+// queue of user's active device.
+func (c *Client) Queue(ctx context.Context, uri URI) error {
+	return c.queue(ctx, uri, "")
+}
+
+// queue adds track or episode identified by uri to the playback queue of
+// device with ID, or of the active device if ID is empty.
+func (c *Client) queue(ctx context.Context, uri URI, id string) error {
+	u, err := itemURI(uri)
+	if err != nil {
+		return err
+	}
+	u = queueURL + url.QueryEscape(u)
+	if id != "" {
+		u += "&device_id=" + url.QueryEscape(id)
+	}
+	return c.sendJSON(ctx, http.MethodPost, u, nil, nil)
+}
@@ -0,0 +1,24 @@
+package spotify
+
+import (
+	"context"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{""}}
+	c := &Client{get: g}
+	if err := c.Queue(context.Background(),
+		"spotify:track:6crBy2sODw2HS53xquM6us"); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	u := queueURL + "spotify%3Atrack%3A6crBy2sODw2HS53xquM6us"
+	if g.u[0] != u || g.m[0] != "POST" {
+		t.Errorf("want POST %q; got %s %q", u, g.m[0], g.u[0])
+	}
+	if err := c.Queue(context.Background(),
+		"spotify:album:4LJbsUCNTcNNNHNiX6qES1"); err == nil {
+		t.Error("want err!=nil for album URI")
+	}
+}
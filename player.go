@@ -0,0 +1,116 @@
+package spotify
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Player is a playback backend, e.g. Spotify desktop app controlled through
+// Dbus or a Spotify Connect device controlled through Web API.
+type Player interface {
+	Play() error   // Play starts playing.
+	Pause() error  // Pause pauses playing.
+	Stop() error   // Stop stops playing.
+	Toggle() error // Toggle toggles between play and pause.
+	Next() error   // Next plays next track.
+	Prev() error   // Prev plays previous track.
+
+	// Goto moves current position by offset, which can be negative.
+	Goto(offset time.Duration) error
+	// SetPos sets current position in the current track.
+	SetPos(pos time.Duration) error
+	// Pos returns current position.
+	Pos() (time.Duration, error)
+	// Open starts playing item with URI.
+	Open(uri URI) error
+
+	// Volume returns volume in range 0-1.
+	Volume() (float64, error)
+	// SetVolume sets volume in range 0-1.
+	SetVolume(v float64) error
+	// Shuffle reports whether shuffle is on.
+	Shuffle() (bool, error)
+	// SetShuffle turns shuffle on or off.
+	SetShuffle(on bool) error
+	// Loop returns loop mode of playback.
+	Loop() (Loop, error)
+	// SetLoop sets loop mode of playback.
+	SetLoop(l Loop) error
+
+	Track() (Track, error)          // Track returns currently played track.
+	Status() (Status, error)        // Status returns status of playback.
+	Length() (time.Duration, error) // Length returns length of the track.
+
+	CanPlay() (bool, error)    // CanPlay reports whether Play is available.
+	CanPause() (bool, error)   // CanPause reports whether Pause is available.
+	CanNext() (bool, error)    // CanNext reports whether Next is available.
+	CanPrev() (bool, error)    // CanPrev reports whether Prev is available.
+	CanSeek() (bool, error)    // CanSeek reports whether seeking is available.
+	CanControl() (bool, error) // CanControl reports whether player is controllable.
+}
+
+// Names of players provided by the package.
+const (
+	PlayerDbus    = "dbus"
+	PlayerConnect = "connect"
+)
+
+// playerPrefs are names of players in order of preference of the default
+// player.
+var playerPrefs = []string{PlayerDbus, PlayerConnect}
+
+// registry is a registry of player factories.
+var registry = struct {
+	sync.Mutex
+	m map[string]func() (Player, error)
+}{m: map[string]func() (Player, error){}}
+
+// RegisterPlayer registers factory f of player with name. Registering
+// player with name of already registered one replaces it. Dbus player is
+// registered on Linux, while Connect has to be registered by users, as it
+// requires an authorized Client:
+//
+//	spotify.RegisterPlayer(spotify.PlayerConnect, func() (Player, error) {
+//		return spotify.NewConnect(c), nil
+//	})
+func RegisterPlayer(name string, f func() (Player, error)) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.m[name] = f
+}
+
+// Players returns sorted names of registered players.
+func Players() []string {
+	registry.Lock()
+	defer registry.Unlock()
+	res := make([]string, 0, len(registry.m))
+	for name := range registry.m {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// NewPlayer returns a new instance of player registered with name. If name
+// is empty, the native player of the platform is preferred, i.e. Dbus on
+// Linux and Connect elsewhere.
+func NewPlayer(name string) (Player, error) {
+	registry.Lock()
+	f, ok := registry.m[name]
+	if name == "" {
+		for _, p := range playerPrefs {
+			if f, ok = registry.m[p]; ok {
+				break
+			}
+		}
+	}
+	registry.Unlock()
+	switch {
+	case ok:
+		return f()
+	case name == "":
+		return nil, errorf("no player is registered")
+	}
+	return nil, errorf("unknown player: %q", name)
+}
@@ -0,0 +1,117 @@
+package spotify
+
+import (
+	"testing"
+	"time"
+)
+
+// fakePlayer is a Player recording called commands.
+type fakePlayer struct {
+	calls   []string
+	pos     time.Duration
+	volume  float64
+	shuffle bool
+	loop    Loop
+	status  Status
+	track   Track
+}
+
+func (f *fakePlayer) call(name string) error {
+	f.calls = append(f.calls, name)
+	return nil
+}
+
+func (f *fakePlayer) Play() error   { f.status = Playing; return f.call("Play") }
+func (f *fakePlayer) Pause() error  { f.status = Paused; return f.call("Pause") }
+func (f *fakePlayer) Stop() error   { f.status = Stopped; return f.call("Stop") }
+func (f *fakePlayer) Toggle() error { return f.call("Toggle") }
+func (f *fakePlayer) Next() error   { return f.call("Next") }
+func (f *fakePlayer) Prev() error   { return f.call("Prev") }
+
+func (f *fakePlayer) Goto(offset time.Duration) error {
+	f.pos += offset
+	return f.call("Goto")
+}
+
+func (f *fakePlayer) SetPos(pos time.Duration) error {
+	f.pos = pos
+	return f.call("SetPos")
+}
+
+func (f *fakePlayer) Pos() (time.Duration, error) { return f.pos, nil }
+
+func (f *fakePlayer) Open(uri URI) error {
+	f.track = Track{URI: string(uri)}
+	return f.call("Open")
+}
+
+func (f *fakePlayer) Volume() (float64, error) { return f.volume, nil }
+
+func (f *fakePlayer) SetVolume(v float64) error {
+	f.volume = v
+	return f.call("SetVolume")
+}
+
+func (f *fakePlayer) Shuffle() (bool, error) { return f.shuffle, nil }
+
+func (f *fakePlayer) SetShuffle(on bool) error {
+	f.shuffle = on
+	return f.call("SetShuffle")
+}
+
+func (f *fakePlayer) Loop() (Loop, error) { return f.loop, nil }
+
+func (f *fakePlayer) SetLoop(l Loop) error {
+	f.loop = l
+	return f.call("SetLoop")
+}
+
+func (f *fakePlayer) Track() (Track, error)          { return f.track, nil }
+func (f *fakePlayer) Status() (Status, error)        { return f.status, nil }
+func (f *fakePlayer) Length() (time.Duration, error) { return f.track.Duration, nil }
+func (f *fakePlayer) CanPlay() (bool, error)         { return true, nil }
+func (f *fakePlayer) CanPause() (bool, error)        { return true, nil }
+func (f *fakePlayer) CanNext() (bool, error)         { return true, nil }
+func (f *fakePlayer) CanPrev() (bool, error)         { return true, nil }
+func (f *fakePlayer) CanSeek() (bool, error)         { return true, nil }
+func (f *fakePlayer) CanControl() (bool, error)      { return true, nil }
+
+var (
+	_ Player = (*fakePlayer)(nil)
+	_ Player = (*Connect)(nil)
+)
+
+func TestRegistry(t *testing.T) {
+	f := &fakePlayer{}
+	RegisterPlayer("fake", func() (Player, error) { return f, nil })
+	defer func(prefs []string) {
+		playerPrefs = prefs
+		registry.Lock()
+		delete(registry.m, "fake")
+		registry.Unlock()
+	}(playerPrefs)
+	playerPrefs = []string{"missing", "fake"}
+	for _, name := range []string{"fake", ""} {
+		p, err := NewPlayer(name)
+		if err != nil {
+			t.Fatalf("want err=nil; got %q (%q)", err, name)
+		}
+		if p != f {
+			t.Errorf("want p=%p; got %p (%q)", f, p, name)
+		}
+	}
+	if _, err := NewPlayer("missing"); err == nil {
+		t.Error("want err!=nil for unknown player")
+	}
+	found := false
+	for _, name := range Players() {
+		found = found || name == "fake"
+	}
+	if !found {
+		t.Errorf("want fake in %v", Players())
+	}
+	playerPrefs = []string{"missing"}
+	if _, err := NewPlayer(""); err == nil {
+		t.Error("want err!=nil for no default player")
+	}
+}
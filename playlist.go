@@ -0,0 +1,202 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// strings used for managing playlists
+const (
+	createPlaylistURL = endPointURL + "users/%s/playlists"
+	playlistURL       = endPointURL + "playlists/%s"
+	playlistItemsURL  = endPointURL + "playlists/%s/tracks"
+)
+
+// playlistBatch is a maximum number of items modified by single request.
+const playlistBatch = 100
+
+// PlaylistDetails are details of a playlist set on its creation or edit.
+// Nil fields are left unchanged on edit.
+type PlaylistDetails struct {
+	Name          string  // Name is the name of the playlist.
+	Description   *string // Description is a description of the playlist.
+	Public        *bool   // Public makes the playlist public or private.
+	Collaborative *bool   // Collaborative allows others to modify playlist.
+}
+
+// json returns request body of d.
+func (d PlaylistDetails) json() playlistDetails {
+	return playlistDetails{
+		Name: d.Name, Description: d.Description, Public: d.Public,
+		Collaborative: d.Collaborative,
+	}
+}
+
+// PlaylistItem is an item of a playlist to remove. If Positions are empty,
+// all occurrences of URI are removed.
+type PlaylistItem struct {
+	URI       URI   // URI is a Spotify URI of track or episode.
+	Positions []int // Positions are zero-based positions of the item.
+}
+
+// CreatePlaylist creates playlist owned by user with userID, who has to be
+// the user authorizing c.
+func (c *Client) CreatePlaylist(ctx context.Context, userID string,
+	d PlaylistDetails) (Playlist, error) {
+	if userID == "" {
+		return Playlist{}, errorf("create playlist: empty user ID")
+	}
+	if d.Name == "" {
+		return Playlist{}, errorf("create playlist: empty name")
+	}
+	var resp playlist
+	if err := c.sendJSON(ctx, http.MethodPost, fmt.Sprintf(createPlaylistURL,
+		userID), d.json(), &resp); err != nil {
+		return Playlist{}, err
+	}
+	return playlists{&resp}.conv()[0], nil
+}
+
+// EditPlaylist changes details of playlist identified by uri.
+func (c *Client) EditPlaylist(ctx context.Context, uri URI,
+	d PlaylistDetails) error {
+	id, err := lookupID(KindPlaylist, uri)
+	if err != nil {
+		return err
+	}
+	return c.sendJSON(ctx, http.MethodPut, fmt.Sprintf(playlistURL, id),
+		d.json(), nil)
+}
+
+// AddItems inserts tracks or episodes identified by uris to playlist
+// identified by uri at position pos, or appends them if pos is negative.
+// Items are added in chunks of 100. It returns snapshot ID of the playlist
+// after the last change.
+func (c *Client) AddItems(ctx context.Context, uri URI, pos int,
+	uris ...URI) (string, error) {
+	id, items, err := playlistItems(uri, uris)
+	if err != nil {
+		return "", err
+	}
+	var snap string
+	for i := 0; i < len(items); i += playlistBatch {
+		req := playlistItemsReq{URIs: items[i:minInt(i+playlistBatch,
+			len(items))]}
+		if pos >= 0 {
+			p := pos + i
+			req.Position = &p
+		}
+		if snap, err = c.snapshot(ctx, http.MethodPost, id, req); err != nil {
+			return "", err
+		}
+	}
+	return snap, nil
+}
+
+// RemoveItems removes items from playlist identified by uri. If snapshot is
+// not empty, positions of items refer to this version of the playlist.
+// Items are removed in chunks of 100. It returns snapshot ID of the playlist
+// after the last change.
+func (c *Client) RemoveItems(ctx context.Context, uri URI, snapshot string,
+	items ...PlaylistItem) (string, error) {
+	id, err := lookupID(KindPlaylist, uri)
+	if err != nil {
+		return "", err
+	}
+	tracks := make([]playlistTrack, len(items))
+	for i := range items {
+		u, err := itemURI(items[i].URI)
+		if err != nil {
+			return "", err
+		}
+		tracks[i] = playlistTrack{URI: u, Positions: items[i].Positions}
+	}
+	snap := snapshot
+	for i := 0; i < len(tracks); i += playlistBatch {
+		if snap, err = c.snapshot(ctx, http.MethodDelete, id, playlistRemoveReq{
+			Tracks:   tracks[i:minInt(i+playlistBatch, len(tracks))],
+			Snapshot: snapshot,
+		}); err != nil {
+			return "", err
+		}
+	}
+	return snap, nil
+}
+
+// ReorderItems moves length items starting at start before item at position
+// before in playlist identified by uri. If snapshot is not empty, positions
+// refer to this version of the playlist. It returns snapshot ID of the
+// playlist after the change.
+func (c *Client) ReorderItems(ctx context.Context, uri URI, start, length,
+	before int, snapshot string) (string, error) {
+	id, err := lookupID(KindPlaylist, uri)
+	if err != nil {
+		return "", err
+	}
+	if start < 0 || length < 1 || before < 0 {
+		return "", errorf("reorder: invalid range: %d+%d before %d", start,
+			length, before)
+	}
+	return c.snapshot(ctx, http.MethodPut, id, playlistReorderReq{
+		Start: start, Length: length, Before: before, Snapshot: snapshot,
+	})
+}
+
+// ReplaceItems replaces all items of playlist identified by uri with tracks
+// or episodes identified by uris. The first 100 items replace the playlist,
+// the rest is appended in chunks of 100. It returns snapshot ID of the
+// playlist after the last change.
+func (c *Client) ReplaceItems(ctx context.Context, uri URI,
+	uris ...URI) (string, error) {
+	id, items, err := playlistItems(uri, uris)
+	if err != nil {
+		return "", err
+	}
+	n := minInt(playlistBatch, len(items))
+	snap, err := c.snapshot(ctx, http.MethodPut, id,
+		playlistItemsReq{URIs: items[:n]})
+	if err != nil || n == len(items) {
+		return snap, err
+	}
+	return c.AddItems(ctx, uri, -1, uris[n:]...)
+}
+
+// snapshot sends request modifying items of playlist with id and returns
+// snapshot ID of the playlist after the change.
+func (c *Client) snapshot(ctx context.Context, method, id string,
+	req interface{}) (string, error) {
+	var resp struct {
+		Snapshot string `json:"snapshot_id"`
+	}
+	err := c.sendJSON(ctx, method, fmt.Sprintf(playlistItemsURL, id), req,
+		&resp)
+	return resp.Snapshot, err
+}
+
+// playlistItems returns ID of playlist uri and canonical URIs of items.
+func playlistItems(uri URI, uris []URI) (string, []string, error) {
+	id, err := lookupID(KindPlaylist, uri)
+	if err != nil {
+		return "", nil, err
+	}
+	items := make([]string, len(uris))
+	for i := range uris {
+		if items[i], err = itemURI(uris[i]); err != nil {
+			return "", nil, err
+		}
+	}
+	return id, items, nil
+}
+
+// itemURI returns canonical form of uri of track or episode.
+func itemURI(uri URI) (string, error) {
+	u, err := ParseURI(string(uri))
+	if err != nil {
+		return "", err
+	}
+	if k := u.Kind(); k != KindTrack && k != KindEpisode {
+		return "", errorf("want track or episode URI; got %q", uri)
+	}
+	return string(u), nil
+}
@@ -0,0 +1,163 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testItems returns n track URIs.
+func testItems(n int) []URI {
+	res := make([]URI, n)
+	for i := range res {
+		res[i] = "spotify:track:6crBy2sODw2HS53xquM6us"
+	}
+	return res
+}
+
+func TestCreatePlaylist(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "playlist.json")}}
+	c := &Client{get: g}
+	desc, public := "best of", false
+	p, err := c.CreatePlaylist(context.Background(), "tenaciousd",
+		PlaylistDetails{Name: "D", Description: &desc, Public: &public})
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if p.URI != "spotify:playlist:37i9dQZF1DZ06evO1tcN2z" {
+		t.Errorf("invalid playlist: %v", p)
+	}
+	if u := endPointURL + "users/tenaciousd/playlists"; g.u[0] != u ||
+		g.m[0] != "POST" {
+		t.Errorf("want POST %q; got %s %q", u, g.m[0], g.u[0])
+	}
+	if b := `{"name":"D","description":"best of","public":false}`; g.b[0] != b {
+		t.Errorf("want body=%s; got %s", b, g.b[0])
+	}
+	if _, err = c.CreatePlaylist(context.Background(), "tenaciousd",
+		PlaylistDetails{}); err == nil {
+		t.Error("want err!=nil for empty name")
+	}
+}
+
+func TestEditPlaylist(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{""}}
+	c := &Client{get: g}
+	if err := c.EditPlaylist(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z",
+		PlaylistDetails{Name: "D"}); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if u := endPointURL + "playlists/37i9dQZF1DZ06evO1tcN2z"; g.u[0] != u ||
+		g.m[0] != "PUT" || g.b[0] != `{"name":"D"}` {
+		t.Errorf("want PUT %q; got %s %q %s", u, g.m[0], g.u[0], g.b[0])
+	}
+}
+
+func TestAddItems(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{`{"snapshot_id":"a"}`, `{"snapshot_id":"b"}`}}
+	c := &Client{get: g}
+	snap, err := c.AddItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z", 10, testItems(150)...)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if snap != "b" {
+		t.Errorf("want snap=b; got %q", snap)
+	}
+	if len(g.b) != 2 {
+		t.Fatalf("want 2 requests; got %d", len(g.b))
+	}
+	for i, want := range []struct{ n, pos int }{{100, 10}, {50, 110}} {
+		var req playlistItemsReq
+		if err = json.Unmarshal([]byte(g.b[i]), &req); err != nil {
+			t.Fatalf("want err=nil; got %q (%d)", err, i)
+		}
+		if len(req.URIs) != want.n || req.Position == nil ||
+			*req.Position != want.pos {
+			t.Errorf("want %d items at %d; got %s (%d)", want.n, want.pos,
+				g.b[i], i)
+		}
+	}
+	if _, err = c.AddItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z", -1,
+		"spotify:album:4LJbsUCNTcNNNHNiX6qES1"); err == nil {
+		t.Error("want err!=nil for album URI")
+	}
+}
+
+func TestRemoveItems(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{`{"snapshot_id":"b"}`}}
+	c := &Client{get: g}
+	snap, err := c.RemoveItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z", "a",
+		PlaylistItem{URI: "spotify:track:6crBy2sODw2HS53xquM6us",
+			Positions: []int{0, 3}},
+		PlaylistItem{URI: "https://open.spotify.com/episode/3USOTQdPtZlgVurwaqsdI5"})
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if snap != "b" || g.m[0] != "DELETE" {
+		t.Errorf("want DELETE with snap=b; got %s %q", g.m[0], snap)
+	}
+	b := `{"tracks":[{"uri":"spotify:track:6crBy2sODw2HS53xquM6us",` +
+		`"positions":[0,3]},` +
+		`{"uri":"spotify:episode:3USOTQdPtZlgVurwaqsdI5"}],"snapshot_id":"a"}`
+	if g.b[0] != b {
+		t.Errorf("want body=%s; got %s", b, g.b[0])
+	}
+}
+
+func TestReorderItems(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{`{"snapshot_id":"b"}`}}
+	c := &Client{get: g}
+	if _, err := c.ReorderItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z", 5, 2, 0, ""); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if b := `{"range_start":5,"range_length":2,"insert_before":0}`; g.b[0] != b {
+		t.Errorf("want body=%s; got %s", b, g.b[0])
+	}
+	if _, err := c.ReorderItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z", 5, 0, 0, ""); err == nil {
+		t.Error("want err!=nil for empty range")
+	}
+}
+
+func TestReplaceItems(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{`{"snapshot_id":"a"}`, `{"snapshot_id":"b"}`,
+		`{"snapshot_id":"c"}`}}
+	c := &Client{get: g}
+	snap, err := c.ReplaceItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z", testItems(150)...)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if snap != "b" {
+		t.Errorf("want snap=b; got %q", snap)
+	}
+	if want := []string{"PUT", "POST"}; !reflect.DeepEqual(g.m, want) {
+		t.Errorf("want methods=%v; got %v", want, g.m)
+	}
+	if n := strings.Count(g.b[1], "spotify:track:"); n != 50 ||
+		strings.Contains(g.b[1], "position") {
+		t.Errorf("want 50 appended items; got %s", g.b[1])
+	}
+	g = &getMock{d: []string{`{"snapshot_id":"a"}`}}
+	c = &Client{get: g}
+	if _, err = c.ReplaceItems(context.Background(),
+		"spotify:playlist:37i9dQZF1DZ06evO1tcN2z"); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if g.b[0] != `{"uris":[]}` {
+		t.Errorf("want empty playlist; got %s", g.b[0])
+	}
+}
@@ -0,0 +1,182 @@
+package spotify
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Query is a search query composed of keywords, field filters and operators.
+// Search.Query validates it before searching:
+//
+//	q := Query{Keywords: "tribute", Artist: "Tenacious D", Year: "2001"}
+//	res, err := s.Query(ctx, q, SearchTrack, PageOpts{})
+//
+// Its String method returns a query, which can be passed to any search
+// method of Search taking a name. These methods validate field filters and
+// quotes of the name as well. Keywords may contain phrases in double quotes.
+type Query struct {
+	Keywords string   // Keywords are matched against all fields of items.
+	Artist   string   // Artist filters items by artist's name.
+	Album    string   // Album filters items by album's name.
+	Track    string   // Track filters items by track's name.
+	Genre    string   // Genre filters artists and tracks by genre.
+	Year     string   // Year is a single year (1990) or a range (1990-1999).
+	ISRC     string   // ISRC filters tracks by their recording code.
+	UPC      string   // UPC filters albums by their product code.
+	New      bool     // New restricts albums to released in past two weeks.
+	Hipster  bool     // Hipster restricts albums to the lowest 10% popularity.
+	Or       []string // Or is a list of keywords alternative to Keywords.
+	Not      []string // Not is a list of keywords excluded from results.
+}
+
+var (
+	reYear = regexp.MustCompile(`^(\d{4})(?:-(\d{4}))?$`)
+	reISRC = regexp.MustCompile(`^[A-Za-z]{2}[A-Za-z0-9]{3}\d{7}$`)
+	reUPC  = regexp.MustCompile(`^\d{12,13}$`)
+)
+
+// Validate checks whether q is a valid query.
+func (q Query) Validate() error {
+	if q.String() == "" {
+		return errorf("query: empty query")
+	}
+	vals := []string{q.Artist, q.Album, q.Track, q.Genre}
+	vals = append(append(vals, q.Or...), q.Not...)
+	for _, v := range vals {
+		if strings.Contains(v, `"`) {
+			return errorf("query: double quote is not allowed: %q", v)
+		}
+	}
+	return validateQuery(q.String())
+}
+
+// validateQuery checks whether double quotes of query q are balanced and its
+// year, isrc, upc and tag filters have valid values.
+func validateQuery(q string) error {
+	if strings.Count(q, `"`)%2 != 0 {
+		return errorf("query: unbalanced double quotes: %q", q)
+	}
+	for _, f := range splitQuery(q) {
+		i := strings.Index(f, ":")
+		if i == -1 {
+			continue
+		}
+		if err := validateFilter(strings.ToLower(f[:i]),
+			strings.Trim(f[i+1:], `"`)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateFilter checks value v of field filter name. Unknown filters are
+// not checked.
+func validateFilter(name, v string) error {
+	switch name {
+	case "year":
+		m := reYear.FindStringSubmatch(v)
+		if m == nil {
+			return errorf("query: invalid year: %q", v)
+		}
+		if m[2] != "" {
+			from, _ := strconv.Atoi(m[1])
+			to, _ := strconv.Atoi(m[2])
+			if from > to {
+				return errorf("query: invalid year range: %q", v)
+			}
+		}
+	case "isrc":
+		if !reISRC.MatchString(v) {
+			return errorf("query: invalid ISRC: %q", v)
+		}
+	case "upc":
+		if !reUPC.MatchString(v) {
+			return errorf("query: invalid UPC: %q", v)
+		}
+	case "tag":
+		if v != "new" && v != "hipster" {
+			return errorf("query: invalid tag: %q", v)
+		}
+	}
+	return nil
+}
+
+// splitQuery splits q into words separated by white space outside of double
+// quotes.
+func splitQuery(q string) []string {
+	var res []string
+	quoted, start := false, -1
+	for i, r := range q {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case !quoted && unicode.IsSpace(r):
+			if start != -1 {
+				res, start = append(res, q[start:i]), -1
+			}
+			continue
+		}
+		if start == -1 {
+			start = i
+		}
+	}
+	if start != -1 {
+		res = append(res, q[start:])
+	}
+	return res
+}
+
+// String implements `Stringer`. It returns query in a format accepted by
+// Web API.
+func (q Query) String() string {
+	var res []string
+	if k := strings.Join(strings.Fields(q.Keywords), " "); k != "" {
+		res = append(res, k)
+	}
+	for _, v := range q.Or {
+		if res = append(res, quote(v)); len(res) > 1 {
+			res[len(res)-2] += " OR"
+		}
+	}
+	for _, f := range []struct{ name, v string }{
+		{"artist", q.Artist}, {"album", q.Album}, {"track", q.Track},
+		{"genre", q.Genre}, {"year", q.Year}, {"isrc", q.ISRC},
+		{"upc", q.UPC},
+	} {
+		if f.v != "" {
+			res = append(res, f.name+":"+quote(f.v))
+		}
+	}
+	if q.New {
+		res = append(res, "tag:new")
+	}
+	if q.Hipster {
+		res = append(res, "tag:hipster")
+	}
+	for _, v := range q.Not {
+		res = append(res, "NOT "+quote(v))
+	}
+	return strings.Join(res, " ")
+}
+
+// Query validates q and searches for items of types t matching it in
+// a single request. Limit and Max of o apply to each type separately.
+func (s *Search) Query(ctx context.Context, q Query, t SearchType,
+	o PageOpts) (*Results, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	return s.All(ctx, q.String(), t, o)
+}
+
+// quote returns v in double quotes if it consists of multiple words.
+func quote(v string) string {
+	v = strings.Join(strings.Fields(v), " ")
+	if strings.Contains(v, " ") {
+		return `"` + v + `"`
+	}
+	return v
+}
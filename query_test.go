@@ -0,0 +1,184 @@
+package spotify
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		q     Query
+		res   string
+		isnil bool
+	}{
+		{
+			q:     Query{Keywords: "tribute"},
+			res:   "tribute",
+			isnil: true,
+		},
+		{
+			q: Query{Keywords: " the  greatest song ", Artist: "Tenacious D",
+				Year: "2001"},
+			res:   `the greatest song artist:"Tenacious D" year:2001`,
+			isnil: true,
+		},
+		{
+			q: Query{Album: "POD", Year: "1990-1999", Genre: "comedy rock",
+				New: true, Hipster: true},
+			res:   `album:POD genre:"comedy rock" year:1990-1999 tag:new tag:hipster`,
+			isnil: true,
+		},
+		{
+			q:     Query{Keywords: "tribute", Or: []string{"kickapoo", "wonderboy"}, Not: []string{"live", "karaoke version"}},
+			res:   `tribute OR kickapoo OR wonderboy NOT live NOT "karaoke version"`,
+			isnil: true,
+		},
+		{
+			q:     Query{ISRC: "USSM10603618", UPC: "886443927087"},
+			res:   "isrc:USSM10603618 upc:886443927087",
+			isnil: true,
+		},
+		{
+			q:     Query{},
+			res:   "",
+			isnil: false,
+		},
+		{
+			q:     Query{Artist: `Tenacious "D"`},
+			res:   `artist:"Tenacious "D""`,
+			isnil: false,
+		},
+		{
+			q:     Query{Keywords: "x", Year: "1999-1990"},
+			res:   "x year:1999-1990",
+			isnil: false,
+		},
+		{
+			q:     Query{Keywords: "x", Year: "90s"},
+			res:   "x year:90s",
+			isnil: false,
+		},
+		{
+			q:     Query{ISRC: "123"},
+			res:   "isrc:123",
+			isnil: false,
+		},
+		{
+			q:     Query{UPC: "abc"},
+			res:   "upc:abc",
+			isnil: false,
+		},
+		{
+			q:     Query{Keywords: `"the greatest song" tribute`},
+			res:   `"the greatest song" tribute`,
+			isnil: true,
+		},
+		{
+			q:     Query{Keywords: `the "greatest song`, Artist: "Tenacious D"},
+			res:   `the "greatest song artist:"Tenacious D"`,
+			isnil: false,
+		},
+	}
+	for i, cas := range cases {
+		if res := cas.q.String(); res != cas.res {
+			t.Errorf("want res=cas.res; got %q=%q (%d)", res, cas.res, i)
+		}
+		if err := cas.q.Validate(); (err == nil) != cas.isnil {
+			t.Errorf("want (err=nil)=isnil; err: %v, isnil: %t (%d)",
+				err, cas.isnil, i)
+		}
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "artist_1.json")}}
+	s := &Search{get: g, batch: 5}
+	if _, err := s.Query(context.Background(), Query{Year: "19x0"},
+		SearchArtist, PageOpts{}); err == nil {
+		t.Error("want err!=nil for invalid query")
+	}
+	if len(g.u) != 0 {
+		t.Errorf("want no request for invalid query; got %q", g.u)
+	}
+	res, err := s.Query(context.Background(), Query{Artist: "Tenacious D"},
+		SearchArtist, PageOpts{})
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if res.Artists == nil || len(g.u) != 1 ||
+		!strings.Contains(g.u[0], "q=artist%3A%22Tenacious+D%22&") {
+		t.Errorf("want artist search for query; got %q", g.u)
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		q     string
+		isnil bool
+	}{
+		{
+			q:     `tribute artist:"Tenacious D" year:2001 tag:new`,
+			isnil: true,
+		},
+		{
+			q:     "spotify:track:6crBy2sODw2HS53xquM6us",
+			isnil: true,
+		},
+		{
+			q:     `"year:19x0"`,
+			isnil: true,
+		},
+		{
+			q:     "tribute year:19x0",
+			isnil: false,
+		},
+		{
+			q:     `tribute YEAR:"2001-1999"`,
+			isnil: false,
+		},
+		{
+			q:     "tribute tag:old",
+			isnil: false,
+		},
+		{
+			q:     "isrc:123 upc:886443927087",
+			isnil: false,
+		},
+		{
+			q:     `artist:"Tenacious D`,
+			isnil: false,
+		},
+	}
+	for i, cas := range cases {
+		if err := validateQuery(cas.q); (err == nil) != cas.isnil {
+			t.Errorf("want (err=nil)=isnil; err: %v, isnil: %t (%d)",
+				err, cas.isnil, i)
+		}
+	}
+}
+
+func TestSearchInvalidQuery(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "artist_1.json")}}
+	s := &Search{get: g, batch: 5}
+	ctx := context.Background()
+	if err := s.ArtistContext(ctx, "tribute year:19x0",
+		make(chan []Artist, 1)); err == nil {
+		t.Error("want err!=nil for invalid query")
+	}
+	p := s.TrackPager(ctx, `"tribute`, PageOpts{})
+	if p.Next() || p.Err() == nil {
+		t.Error("want pager to fail for invalid query")
+	}
+	if _, err := s.All(ctx, "tribute tag:old", SearchArtist,
+		PageOpts{}); err == nil {
+		t.Error("want err!=nil for invalid query")
+	}
+	if len(g.u) != 0 {
+		t.Errorf("want no request for invalid query; got %q", g.u)
+	}
+}
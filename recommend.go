@@ -0,0 +1,215 @@
+package spotify
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Attribute is a tunable audio attribute of recommended tracks.
+type Attribute string
+
+// Tunable attributes of recommended tracks.
+const (
+	AttrAcousticness     Attribute = "acousticness"
+	AttrDanceability     Attribute = "danceability"
+	AttrDurationMs       Attribute = "duration_ms"
+	AttrEnergy           Attribute = "energy"
+	AttrInstrumentalness Attribute = "instrumentalness"
+	AttrKey              Attribute = "key"
+	AttrLiveness         Attribute = "liveness"
+	AttrLoudness         Attribute = "loudness"
+	AttrMode             Attribute = "mode"
+	AttrPopularity       Attribute = "popularity"
+	AttrSpeechiness      Attribute = "speechiness"
+	AttrTempo            Attribute = "tempo"
+	AttrTimeSignature    Attribute = "time_signature"
+	AttrValence          Attribute = "valence"
+)
+
+// attrRange is a range of valid values of an attribute.
+type attrRange struct {
+	min, max float64
+	integer  bool // integer reports whether values have to be integers.
+}
+
+// attrRanges are ranges of values of all tunable attributes.
+var attrRanges = map[Attribute]attrRange{
+	AttrAcousticness:     {0, 1, false},
+	AttrDanceability:     {0, 1, false},
+	AttrDurationMs:       {0, math.Inf(1), true},
+	AttrEnergy:           {0, 1, false},
+	AttrInstrumentalness: {0, 1, false},
+	AttrKey:              {0, 11, true},
+	AttrLiveness:         {0, 1, false},
+	AttrLoudness:         {math.Inf(-1), math.Inf(1), false},
+	AttrMode:             {0, 1, true},
+	AttrPopularity:       {0, 100, true},
+	AttrSpeechiness:      {0, 1, false},
+	AttrTempo:            {0, math.Inf(1), false},
+	AttrTimeSignature:    {3, 7, true},
+	AttrValence:          {0, 1, false},
+}
+
+// maxSeeds is a maximum total number of seeds of recommendations.
+const maxSeeds = 5
+
+// maxRecommendations is a maximum number of requested recommendations.
+const maxRecommendations = 100
+
+// recommendationsURL is an URL of recommendations endpoint.
+const recommendationsURL = endPointURL + "recommendations?"
+
+// Recommendations is a request for tracks similar to seed artists, tracks
+// and genres. Recommended tracks can be tuned by minimum, maximum and target
+// values of their audio attributes:
+//
+//	r := Recommendations{
+//		SeedTracks: []URI{"spotify:track:6crBy2sODw2HS53xquM6us"},
+//		Min:        map[Attribute]float64{AttrEnergy: 0.6},
+//		Target:     map[Attribute]float64{AttrTempo: 120},
+//	}
+//	tracks, err := c.Recommend(ctx, r)
+type Recommendations struct {
+	SeedArtists []URI    // SeedArtists is a list of seed artists.
+	SeedTracks  []URI    // SeedTracks is a list of seed tracks.
+	SeedGenres  []string // SeedGenres is a list of seed genres.
+	Market      string   // Market is a country code of available tracks.
+	Limit       int      // Limit is a number of tracks, 0 means default.
+
+	Min    map[Attribute]float64 // Min are minimum values of attributes.
+	Max    map[Attribute]float64 // Max are maximum values of attributes.
+	Target map[Attribute]float64 // Target are target values of attributes.
+}
+
+// Validate checks whether r is a valid request. There has to be at least one
+// and at most five seeds in total.
+func (r Recommendations) Validate() error {
+	_, err := r.values()
+	return err
+}
+
+// values returns query parameters of r.
+func (r Recommendations) values() (url.Values, error) {
+	n := len(r.SeedArtists) + len(r.SeedTracks) + len(r.SeedGenres)
+	if n == 0 || n > maxSeeds {
+		return nil, errorf("recommendations: want 1-%d seeds; got %d",
+			maxSeeds, n)
+	}
+	if r.Limit < 0 || r.Limit > maxRecommendations {
+		return nil, errorf("recommendations: invalid limit: %d", r.Limit)
+	}
+	v := url.Values{}
+	for _, s := range []struct {
+		name, kind string
+		uris       []URI
+	}{
+		{"seed_artists", KindArtist, r.SeedArtists},
+		{"seed_tracks", KindTrack, r.SeedTracks},
+	} {
+		ids := make([]string, len(s.uris))
+		for i := range s.uris {
+			id, err := lookupID(s.kind, s.uris[i])
+			if err != nil {
+				return nil, err
+			}
+			ids[i] = id
+		}
+		if len(ids) > 0 {
+			v.Set(s.name, strings.Join(ids, ","))
+		}
+	}
+	for _, g := range r.SeedGenres {
+		if strings.TrimSpace(g) == "" || strings.Contains(g, ",") {
+			return nil, errorf("recommendations: invalid genre: %q", g)
+		}
+	}
+	if len(r.SeedGenres) > 0 {
+		v.Set("seed_genres", strings.Join(r.SeedGenres, ","))
+	}
+	if r.Market != "" {
+		v.Set("market", r.Market)
+	}
+	if r.Limit > 0 {
+		v.Set("limit", strconv.Itoa(r.Limit))
+	}
+	for _, t := range []struct {
+		prefix string
+		attrs  map[Attribute]float64
+	}{
+		{"min_", r.Min}, {"max_", r.Max}, {"target_", r.Target},
+	} {
+		for a, x := range t.attrs {
+			if err := checkAttr(a, x); err != nil {
+				return nil, err
+			}
+			v.Set(t.prefix+string(a), strconv.FormatFloat(x, 'f', -1, 64))
+		}
+	}
+	return v, r.checkBounds()
+}
+
+// checkAttr checks whether x is a valid value of attribute a.
+func checkAttr(a Attribute, x float64) error {
+	rng, ok := attrRanges[a]
+	if !ok {
+		return errorf("recommendations: unknown attribute: %q", a)
+	}
+	if math.IsNaN(x) || x < rng.min || x > rng.max ||
+		(rng.integer && x != math.Trunc(x)) {
+		return errorf("recommendations: invalid %s: %v", a, x)
+	}
+	return nil
+}
+
+// checkBounds checks whether minimum values of attributes do not exceed
+// maximum values and target values are within them.
+func (r Recommendations) checkBounds() error {
+	for _, a := range attributes() {
+		min, hasMin := r.Min[a]
+		max, hasMax := r.Max[a]
+		t, hasT := r.Target[a]
+		switch {
+		case hasMin && hasMax && min > max:
+			return errorf("recommendations: min %s exceeds max", a)
+		case hasT && ((hasMin && t < min) || (hasMax && t > max)):
+			return errorf("recommendations: target %s out of bounds", a)
+		}
+	}
+	return nil
+}
+
+// attributes returns sorted list of all tunable attributes.
+func attributes() []Attribute {
+	res := make([]Attribute, 0, len(attrRanges))
+	for a := range attrRanges {
+		res = append(res, a)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
+
+// Recommend returns tracks recommended for request r.
+func (c *Client) Recommend(ctx context.Context, r Recommendations) ([]Track,
+	error) {
+	v, err := r.values()
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Tracks []*trackData `json:"tracks"`
+	}
+	if err = c.getJSON(ctx, recommendationsURL+v.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	var res []Track
+	for _, t := range resp.Tracks {
+		if t != nil {
+			res = append(res, t.conv())
+		}
+	}
+	return res, nil
+}
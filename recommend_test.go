@@ -0,0 +1,101 @@
+package spotify
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	t.Parallel()
+	g := &getMock{d: []string{jsonData(t, "recommendations.json")}}
+	c := &Client{get: g}
+	res, err := c.Recommend(context.Background(), Recommendations{
+		SeedArtists: []URI{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh"},
+		SeedTracks: []URI{
+			"https://open.spotify.com/track/6crBy2sODw2HS53xquM6us",
+		},
+		SeedGenres: []string{"comedy rock"},
+		Limit:      2,
+		Min:        map[Attribute]float64{AttrEnergy: 0.5},
+		Max:        map[Attribute]float64{AttrTempo: 140},
+		Target:     map[Attribute]float64{AttrKey: 4},
+	})
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if !reflect.DeepEqual(res, searchTrackFixt.res[0]) {
+		t.Errorf("want %v; got %v", searchTrackFixt.res[0], res)
+	}
+	u, err := url.Parse(g.u[0])
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	want := url.Values{
+		"seed_artists": {"1XpDYCrUJnvCo9Ez6yeMWh"},
+		"seed_tracks":  {"6crBy2sODw2HS53xquM6us"},
+		"seed_genres":  {"comedy rock"},
+		"limit":        {"2"},
+		"min_energy":   {"0.5"},
+		"max_tempo":    {"140"},
+		"target_key":   {"4"},
+	}
+	if v := u.Query(); !reflect.DeepEqual(v, want) {
+		t.Errorf("want query=%v; got %v", want, v)
+	}
+	if !strings.HasPrefix(g.u[0], recommendationsURL) {
+		t.Errorf("want url with prefix %q; got %q", recommendationsURL, g.u[0])
+	}
+}
+
+func TestRecommendationsValidate(t *testing.T) {
+	t.Parallel()
+	seed := []URI{"spotify:track:6crBy2sODw2HS53xquM6us"}
+	cases := []struct {
+		r     Recommendations
+		isnil bool
+	}{
+		{Recommendations{SeedGenres: []string{"rock"}}, true},
+		{Recommendations{}, false},
+		{Recommendations{SeedGenres: []string{"a", "b", "c", "d", "e", "f"}},
+			false},
+		{Recommendations{SeedTracks: []URI{"spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh"}},
+			false},
+		{Recommendations{SeedTracks: seed, Limit: 101}, false},
+		{Recommendations{SeedTracks: seed,
+			Min: map[Attribute]float64{"loudness": -20}}, true},
+		{Recommendations{SeedTracks: seed,
+			Min: map[Attribute]float64{"weirdness": 1}}, false},
+		{Recommendations{SeedTracks: seed,
+			Target: map[Attribute]float64{AttrEnergy: 1.5}}, false},
+		{Recommendations{SeedTracks: seed,
+			Target: map[Attribute]float64{AttrKey: 2.5}}, false},
+		{Recommendations{SeedTracks: seed,
+			Target: map[Attribute]float64{AttrValence: math.NaN()}}, false},
+		{Recommendations{SeedTracks: seed,
+			Target: map[Attribute]float64{AttrTimeSignature: 4}}, true},
+		{Recommendations{SeedTracks: seed,
+			Min: map[Attribute]float64{AttrTimeSignature: 2}}, false},
+		{Recommendations{SeedTracks: seed,
+			Max: map[Attribute]float64{AttrTimeSignature: 11}}, false},
+		{Recommendations{SeedTracks: seed,
+			Min: map[Attribute]float64{AttrTempo: 120},
+			Max: map[Attribute]float64{AttrTempo: 100}}, false},
+		{Recommendations{SeedTracks: seed,
+			Min:    map[Attribute]float64{AttrTempo: 100},
+			Max:    map[Attribute]float64{AttrTempo: 120},
+			Target: map[Attribute]float64{AttrTempo: 130}}, false},
+		{Recommendations{SeedTracks: seed,
+			Min:    map[Attribute]float64{AttrTempo: 100},
+			Max:    map[Attribute]float64{AttrTempo: 120},
+			Target: map[Attribute]float64{AttrTempo: 110}}, true},
+	}
+	for i, cas := range cases {
+		if err := cas.r.Validate(); (err == nil) != cas.isnil {
+			t.Errorf("want isnil=%t; got err=%v (%d)", cas.isnil, err, i)
+		}
+	}
+}
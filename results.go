@@ -0,0 +1,123 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+// SearchType is a set of types of items searched for by Search.All.
+type SearchType uint
+
+// Types of items which can be searched for.
+const (
+	SearchArtist SearchType = 1 << iota
+	SearchAlbum
+	SearchTrack
+	SearchPlaylist
+	SearchShow
+	SearchEpisode
+	SearchAudiobook
+
+	// SearchAllTypes is a set of all types of items.
+	SearchAllTypes = SearchArtist | SearchAlbum | SearchTrack |
+		SearchPlaylist | SearchShow | SearchEpisode | SearchAudiobook
+)
+
+// searchTypes maps types of items to their names used by Web API.
+var searchTypes = []struct {
+	t    SearchType
+	name string
+}{
+	{SearchArtist, queryArtist},
+	{SearchAlbum, queryAlbum},
+	{SearchTrack, queryTrack},
+	{SearchPlaylist, queryPlaylist},
+	{SearchShow, queryShow},
+	{SearchEpisode, queryEpisode},
+	{SearchAudiobook, queryAudiobook},
+}
+
+// String implements `Stringer`. It returns comma separated list of types
+// as accepted by Web API.
+func (t SearchType) String() string {
+	var res []string
+	for _, st := range searchTypes {
+		if t&st.t != 0 {
+			res = append(res, st.name)
+		}
+	}
+	return strings.Join(res, ",")
+}
+
+// Results is a result of search for multiple types of items. Pagers
+// of requested types start with pages obtained in a single request, further
+// pages are fetched separately for each type. Pagers of types which were not
+// requested are nil.
+type Results struct {
+	Artists    *Pager[Artist]    // Artists is a Pager over found artists.
+	Albums     *Pager[Album]     // Albums is a Pager over found albums.
+	Tracks     *Pager[Track]     // Tracks is a Pager over found tracks.
+	Playlists  *Pager[Playlist]  // Playlists is a Pager over found playlists.
+	Shows      *Pager[Show]      // Shows is a Pager over found shows.
+	Episodes   *Pager[Episode]   // Episodes is a Pager over found episodes.
+	Audiobooks *Pager[Audiobook] // Audiobooks is a Pager over found audiobooks.
+}
+
+// All searches for items of types t with requested name in a single request.
+// Limit and Max of o apply to each type separately.
+func (s *Search) All(ctx context.Context, name string, t SearchType,
+	o PageOpts) (*Results, error) {
+	if t&SearchAllTypes == 0 {
+		return nil, errorf("no search type requested")
+	}
+	if err := validateQuery(name); err != nil {
+		return nil, &SearchError{t.String(), name, uint(o.Offset), err}
+	}
+	o = s.opts(o)
+	u := fmt.Sprintf(queryURL, url.QueryEscape(name), t) + "&" +
+		o.values().Encode()
+	r, err := s.get.get(ctx, u)
+	if err != nil {
+		return nil, &SearchError{t.String(), name, uint(o.Offset), err}
+	}
+	defer r.Body.Close()
+	b, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		err = unmarshal(b, &struct{}{})
+	}
+	if err != nil {
+		return nil, &SearchError{t.String(), name, uint(o.Offset), err}
+	}
+	return s.results(ctx, t, b, o), nil
+}
+
+// results returns Results of types t starting with the response body b.
+func (s *Search) results(ctx context.Context, t SearchType, b []byte,
+	o PageOpts) *Results {
+	res := &Results{}
+	if t&SearchArtist != 0 {
+		res.Artists = prefetched(ctx, s.get, b, o, s.decodeArtists)
+	}
+	if t&SearchAlbum != 0 {
+		res.Albums = prefetched(ctx, s.get, b, o, s.decodeAlbums)
+	}
+	if t&SearchTrack != 0 {
+		res.Tracks = prefetched(ctx, s.get, b, o, s.decodeTracks)
+	}
+	if t&SearchPlaylist != 0 {
+		res.Playlists = prefetched(ctx, s.get, b, o, s.decodePlaylists)
+	}
+	if t&SearchShow != 0 {
+		res.Shows = prefetched(ctx, s.get, b, o, s.decodeShows)
+	}
+	if t&SearchEpisode != 0 {
+		res.Episodes = prefetched(ctx, s.get, b, o, s.decodeEpisodes)
+	}
+	if t&SearchAudiobook != 0 {
+		res.Audiobooks = prefetched(ctx, s.get, b, o, s.decodeAudiobooks)
+	}
+	return res
+}
@@ -0,0 +1,151 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RetryPolicy configures retrying of failed Web API requests. Requests are
+// retried if Web API responds with 429 Too Many Requests, 5xx status or
+// the request fails due to a network error. Non-idempotent (POST) requests
+// are retried only on 429 Too Many Requests, as other failures may occur
+// after the request was processed.
+type RetryPolicy struct {
+	MaxRetries int           // MaxRetries is a maximum number of retries.
+	MinBackoff time.Duration // MinBackoff is a delay before the first retry.
+	MaxBackoff time.Duration // MaxBackoff is a maximum delay between retries.
+}
+
+// DefaultRetryPolicy is a RetryPolicy used by Client unless configured
+// otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 5,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+}
+
+// backoff returns jittered exponential delay before retry number n.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.MinBackoff
+	for i := 0; i < n && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter returns delay requested by Retry-After header of r. It returns
+// false if the header is missing or invalid.
+func retryAfter(r *http.Response) (time.Duration, bool) {
+	h := r.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+	if s, err := strconv.Atoi(h); err == nil && s >= 0 {
+		return time.Duration(s) * time.Second, true
+	}
+	if t, err := http.ParseTime(h); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// RetryStats are statistics of requests sent by Client.
+type RetryStats struct {
+	Requests     int64         // Requests is a number of sent requests, including retries.
+	Retries      int64         // Retries is a number of retried requests.
+	RateLimited  int64         // RateLimited is a number of 429 responses.
+	ServerErrors int64         // ServerErrors is a number of 5xx responses.
+	NetErrors    int64         // NetErrors is a number of network errors.
+	Waited       time.Duration // Waited is a total time spent waiting for retries.
+}
+
+// retryStats collects RetryStats concurrently.
+type retryStats struct {
+	requests, retries, limited, server, network, waited int64
+}
+
+// snapshot returns current values of s.
+func (s *retryStats) snapshot() RetryStats {
+	return RetryStats{
+		Requests:     atomic.LoadInt64(&s.requests),
+		Retries:      atomic.LoadInt64(&s.retries),
+		RateLimited:  atomic.LoadInt64(&s.limited),
+		ServerErrors: atomic.LoadInt64(&s.server),
+		NetErrors:    atomic.LoadInt64(&s.network),
+		Waited:       time.Duration(atomic.LoadInt64(&s.waited)),
+	}
+}
+
+// delay returns a delay before retry number n of request with method, which
+// resulted in r and err. It returns false if the request should not be
+// retried.
+func (g *get) delay(ctx context.Context, method string, n int,
+	r *http.Response, err error) (time.Duration, bool) {
+	var uerr *url.Error
+	idem := method != http.MethodPost
+	switch {
+	case err != nil && errors.As(err, &uerr) && ctx.Err() == nil:
+		atomic.AddInt64(&g.stats.network, 1)
+		if !idem {
+			return 0, false
+		}
+	case err != nil:
+		return 0, false
+	case r.StatusCode == http.StatusTooManyRequests:
+		atomic.AddInt64(&g.stats.limited, 1)
+		if d, ok := retryAfter(r); ok {
+			return d, n < g.policy.MaxRetries
+		}
+	case r.StatusCode >= 500:
+		atomic.AddInt64(&g.stats.server, 1)
+		if !idem {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	return g.policy.backoff(n), n < g.policy.MaxRetries
+}
+
+// retry sends request to url retrying it according to g.policy. Retrying
+// stops if waiting for the next attempt would exceed deadline of ctx,
+// in which case the last response is returned.
+func (g *get) retry(ctx context.Context, method, url string,
+	body []byte) (*http.Response, error) {
+	for n := 0; ; n++ {
+		r, err := g.do(ctx, method, url, body)
+		atomic.AddInt64(&g.stats.requests, 1)
+		d, ok := g.delay(ctx, method, n, r, err)
+		dl, has := ctx.Deadline()
+		if !ok || (has && time.Now().Add(d).After(dl)) {
+			return r, err
+		}
+		if r != nil {
+			r.Body.Close()
+		}
+		atomic.AddInt64(&g.stats.retries, 1)
+		atomic.AddInt64(&g.stats.waited, int64(d))
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer responds with statuses from s in order and with 200 OK
+// afterwards.
+func flakyServer(s []int, retryAfter string, cnt *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			n := int(atomic.AddInt32(cnt, 1)) - 1
+			if n >= len(s) {
+				w.Write([]byte(`{}`))
+				return
+			}
+			if s[n] == http.StatusTooManyRequests && retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(s[n])
+		}))
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		method     string
+		statuses   []int
+		retryAfter string
+		maxRetries int
+		timeout    time.Duration
+		status     int
+		stats      RetryStats
+	}{
+		{
+			statuses:   []int{429, 503, 500},
+			retryAfter: "0",
+			maxRetries: 5,
+			status:     200,
+			stats: RetryStats{Requests: 4, Retries: 3, RateLimited: 1,
+				ServerErrors: 2},
+		},
+		{
+			statuses:   []int{502, 502, 502},
+			maxRetries: 2,
+			status:     502,
+			stats:      RetryStats{Requests: 3, Retries: 2, ServerErrors: 3},
+		},
+		{
+			statuses:   []int{429},
+			retryAfter: "60",
+			maxRetries: 5,
+			timeout:    time.Second,
+			status:     429,
+			stats:      RetryStats{Requests: 1, RateLimited: 1},
+		},
+		{
+			statuses:   []int{404},
+			maxRetries: 5,
+			status:     404,
+			stats:      RetryStats{Requests: 1},
+		},
+		{
+			method:     http.MethodPost,
+			statuses:   []int{429, 503},
+			retryAfter: "0",
+			maxRetries: 5,
+			status:     503,
+			stats: RetryStats{Requests: 2, Retries: 1, RateLimited: 1,
+				ServerErrors: 1},
+		},
+	}
+	for i, cas := range cases {
+		var cnt int32
+		srv := flakyServer(cas.statuses, cas.retryAfter, &cnt)
+		g := newGet(nil)
+		g.policy = RetryPolicy{MaxRetries: cas.maxRetries,
+			MinBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+		ctx, cancel := context.Background(), func() {}
+		if cas.timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, cas.timeout)
+		}
+		start := time.Now()
+		if cas.method == "" {
+			cas.method = http.MethodGet
+		}
+		r, err := g.send(ctx, cas.method, srv.URL, nil)
+		cancel()
+		srv.Close()
+		if err != nil {
+			t.Errorf("want err=nil; got %q (%d)", err, i)
+			continue
+		}
+		r.Body.Close()
+		if r.StatusCode != cas.status {
+			t.Errorf("want r.StatusCode=cas.status; got %d=%d (%d)",
+				r.StatusCode, cas.status, i)
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			t.Errorf("want d<5s; got %v (%d)", d, i)
+		}
+		st := g.stats.snapshot()
+		st.Waited = 0
+		if st != cas.stats {
+			t.Errorf("want st=cas.stats; got %+v=%+v (%d)", st, cas.stats, i)
+		}
+	}
+}
+
+func TestRetryNetError(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	u := srv.URL
+	srv.Close()
+	g := newGet(nil)
+	g.policy = RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond}
+	if _, err := g.get(context.Background(), u); err == nil {
+		t.Fatal("want err!=nil")
+	}
+	if st := g.stats.snapshot(); st.NetErrors != 3 || st.Retries != 2 {
+		t.Errorf("want {NetErrors: 3, Retries: 2}; got %+v", st)
+	}
+}
+
+func TestRetryNetErrorPost(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	u := srv.URL
+	srv.Close()
+	g := newGet(nil)
+	g.policy = RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond,
+		MaxBackoff: time.Millisecond}
+	if _, err := g.send(context.Background(), http.MethodPost, u,
+		nil); err == nil {
+		t.Fatal("want err!=nil")
+	}
+	if st := g.stats.snapshot(); st.NetErrors != 1 || st.Retries != 0 {
+		t.Errorf("want {NetErrors: 1, Retries: 0}; got %+v", st)
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	t.Parallel()
+	var cnt int32
+	srv := flakyServer([]int{503, 503}, "", &cnt)
+	defer srv.Close()
+	g := newGet(nil)
+	g.policy = RetryPolicy{MaxRetries: 5, MinBackoff: time.Minute,
+		MaxBackoff: time.Minute}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	if _, err := g.get(ctx, srv.URL); err != context.Canceled {
+		t.Errorf("want err=context.Canceled; got %v", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second}
+	for n, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		if d := p.backoff(n); d < max/2 || d > max {
+			t.Errorf("want %v<=d<=%v; got %v (%d)", max/2, max, d, n)
+		}
+	}
+}
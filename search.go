@@ -1,16 +1,13 @@
 package spotify
 
 import (
-	"crypto/tls"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"net"
-	"net/http"
 	"net/url"
-	"reflect"
 	"strings"
-	"time"
+	"sync"
 )
 
 // Search implements operations for searching through Spotify Web API.
@@ -19,12 +16,18 @@ type Search struct {
 	batch uint  // batch represents number of read positions.
 }
 
-// NewSearch returns Search instance.
+// NewSearch returns Search instance sending unauthorized requests.
+//
+// Deprecated: Web API rejects unauthorized requests, use
+// NewSearchWithTokenSource instead.
 func NewSearch() *Search {
-	return &Search{
-		get:   newGet(),
-		batch: 50,
-	}
+	return NewSearchWithTokenSource(nil)
+}
+
+// NewSearchWithTokenSource returns Search instance. Requests are authorized
+// with tokens obtained from ts, e.g. NewClientCredentials(id, secret).
+func NewSearchWithTokenSource(ts TokenSource) *Search {
+	return NewClient(ts).Search()
 }
 
 // errEOF is returned when there is no more data to be returned.
@@ -36,105 +39,309 @@ func IsEOF(err error) bool {
 	return err == errEOF
 }
 
+// SearchError is returned when search fails. It records which query failed
+// and at which offset.
+type SearchError struct {
+	Type   string // Type is a type of searched items: artist, album or track.
+	Query  string // Query is a searched query.
+	Offset uint   // Offset is an offset of a page which failed to be read.
+	Err    error  // Err is an underlying error.
+}
+
+// Error implements `error`.
+func (e *SearchError) Error() string {
+	return fmt.Sprintf("[spotify]: %s search for %q failed at offset %d: %v",
+		e.Type, e.Query, e.Offset, e.Err)
+}
+
+// Unwrap returns underlying error.
+func (e *SearchError) Unwrap() error {
+	return e.Err
+}
+
 // Artist searches for requested artists. name is the name of searched artist,
 // c chan is used to return found artists and err i used to return
-// search errors.
+// search errors. It is a wrapper of ArtistContext, which sends errEOF through
+// errch when all artists were sent.
 func (s *Search) Artist(name string, c chan<- []Artist, errch chan<- error) {
-	go s.search("artist", name, c, &artistResp{}, errch, custom)
+	go legacy(func(c chan<- []Artist) error {
+		return s.ArtistContext(context.Background(), name, c)
+	}, c, errch)
 }
 
 // Album searches for requested albums. name is the name of searched album,
 // c chan is used to return found albums and err i used to return
-// search errors.
+// search errors. It is a wrapper of AlbumContext, which sends errEOF through
+// errch when all albums were sent.
 func (s *Search) Album(name string, c chan<- []Album, errch chan<- error) {
-	go s.search("album", name, c, &albumResp{}, errch, (*Search).lookupAlbums)
+	go legacy(func(c chan<- []Album) error {
+		return s.AlbumContext(context.Background(), name, c)
+	}, c, errch)
 }
 
 // Track searches for requested tracks. name is the name of searched track,
 // c chan is used to return found tracks and err i used to return
-// search errors.
+// search errors. It is a wrapper of TrackContext, which sends errEOF through
+// errch when all tracks were sent.
 func (s *Search) Track(name string, c chan<- []Track, errch chan<- error) {
-	go s.search("track", name, c, &trackResp{}, errch, custom)
+	go legacy(func(c chan<- []Track) error {
+		return s.TrackContext(context.Background(), name, c)
+	}, c, errch)
 }
 
-var custom = func(_ *Search, _ interface{}) (_ error) {
-	return
+// ArtistContext searches for requested artists and sends them through c page
+// by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) ArtistContext(ctx context.Context, name string,
+	c chan<- []Artist) error {
+	return send(ctx, s.ArtistPager(ctx, name, PageOpts{}), c, queryArtist, name)
 }
 
-// search searches for requested artist/album/track and sends results through
-// channel when they are available.
-func (s *Search) search(tag, value string, r, resp interface{},
-	errch chan<- error, f func(*Search, interface{}) error) {
-	p, e, m := uint(0), error(nil), resp
-	for {
-		if e = s.read(tag, value, p, s.batch, resp); e != nil && !IsEOF(e) {
-			errch <- e
-			return
-		}
-		u := conv(resp)
-		if err := f(s, u); err != nil {
-			errch <- err
-		}
-		p += s.batch
-		sendRes(r, u)
-		if IsEOF(e) {
-			errch <- e
-			return
-		}
-		resp = reflect.New(reflect.TypeOf(m).Elem()).Interface()
+// AlbumContext searches for requested albums and sends them through c page
+// by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) AlbumContext(ctx context.Context, name string,
+	c chan<- []Album) error {
+	return send(ctx, s.AlbumPager(ctx, name, PageOpts{}), c, queryAlbum, name)
+}
+
+// TrackContext searches for requested tracks and sends them through c page
+// by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) TrackContext(ctx context.Context, name string,
+	c chan<- []Track) error {
+	return send(ctx, s.TrackPager(ctx, name, PageOpts{}), c, queryTrack, name)
+}
+
+// ArtistPager returns Pager over artists with requested name.
+func (s *Search) ArtistPager(ctx context.Context, name string,
+	o PageOpts) *Pager[Artist] {
+	return searchPager(ctx, s, queryArtist, name, o, s.decodeArtists)
+}
+
+// decodeArtists decodes page of artists found by search.
+func (s *Search) decodeArtists(_ context.Context,
+	b []byte) ([]Artist, respHeader, error) {
+	var resp artistResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
 	}
+	return conv(&resp).([]Artist), resp.Artists.respHeader, nil
 }
 
-var errInvResp = errorf("creating response failed")
+// AlbumPager returns Pager over albums with requested name. Artists of
+// the albums are looked up for each page.
+func (s *Search) AlbumPager(ctx context.Context, name string,
+	o PageOpts) *Pager[Album] {
+	return searchPager(ctx, s, queryAlbum, name, o, s.decodeAlbums)
+}
 
-// strings used for interacting with Spotify Web API
-const (
-	endPointURL    = "https://api.spotify.com/v1/"
-	queryURL       = endPointURL + "search?q=%s&type=%s&offset=%d&limit=%d"
-	lookupURL      = endPointURL + "%s/%s"
-	queryArtist    = "artist"
-	queryAlbum     = "album"
-	queryTrack     = "track"
-	lookupAlbum    = "albums"
-	albumURIPrefix = "spotify:album:"
-)
+// decodeAlbums decodes page of albums found by search.
+func (s *Search) decodeAlbums(ctx context.Context,
+	b []byte) ([]Album, respHeader, error) {
+	var resp albumResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
+	}
+	res := conv(&resp).([]Album)
+	if err := s.lookupAlbums(ctx, res); err != nil {
+		return nil, respHeader{}, err
+	}
+	return res, resp.Albums.respHeader, nil
+}
 
-const (
-	resCount = "Total"
-	next     = "Next"
-)
+// TrackPager returns Pager over tracks with requested name.
+func (s *Search) TrackPager(ctx context.Context, name string,
+	o PageOpts) *Pager[Track] {
+	return searchPager(ctx, s, queryTrack, name, o, s.decodeTracks)
+}
 
-// read creates HTTP request based on provided search keyword t, value val,
-// limit of elements to obtain lim and stores result in resp.
-// If no more data is available to return, it returns errEOF and stores
-// remaining data in resp.
-func (s *Search) read(t, val string, off, lim uint, resp interface{}) error {
-	r, err := s.get.get(fmt.Sprintf(queryURL, url.QueryEscape(val), t, off, lim))
-	if err != nil {
-		return err
+// decodeTracks decodes page of tracks found by search.
+func (s *Search) decodeTracks(_ context.Context,
+	b []byte) ([]Track, respHeader, error) {
+	var resp trackResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
 	}
-	defer r.Body.Close()
-	var body []byte
-	if body, err = ioutil.ReadAll(r.Body); err != nil {
-		return err
+	return conv(&resp).([]Track), resp.Tracks.respHeader, nil
+}
+
+// PlaylistContext searches for requested playlists and sends them through c
+// page by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) PlaylistContext(ctx context.Context, name string,
+	c chan<- []Playlist) error {
+	return send(ctx, s.PlaylistPager(ctx, name, PageOpts{}), c, queryPlaylist,
+		name)
+}
+
+// PlaylistPager returns Pager over playlists with requested name.
+func (s *Search) PlaylistPager(ctx context.Context, name string,
+	o PageOpts) *Pager[Playlist] {
+	return searchPager(ctx, s, queryPlaylist, name, o, s.decodePlaylists)
+}
+
+// decodePlaylists decodes page of playlists found by search.
+func (s *Search) decodePlaylists(_ context.Context,
+	b []byte) ([]Playlist, respHeader, error) {
+	var resp playlistResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
 	}
-	if err = unmarshal(body, resp); err != nil {
-		return err
+	return conv(&resp).([]Playlist), resp.Playlists.respHeader, nil
+}
+
+// ShowContext searches for requested shows and sends them through c page
+// by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) ShowContext(ctx context.Context, name string,
+	c chan<- []Show) error {
+	return send(ctx, s.ShowPager(ctx, name, PageOpts{}), c, queryShow, name)
+}
+
+// ShowPager returns Pager over shows with requested name.
+func (s *Search) ShowPager(ctx context.Context, name string,
+	o PageOpts) *Pager[Show] {
+	return searchPager(ctx, s, queryShow, name, o, s.decodeShows)
+}
+
+// decodeShows decodes page of shows found by search.
+func (s *Search) decodeShows(_ context.Context,
+	b []byte) ([]Show, respHeader, error) {
+	var resp showResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
+	}
+	return conv(&resp).([]Show), resp.Shows.respHeader, nil
+}
+
+// EpisodeContext searches for requested episodes and sends them through c page
+// by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) EpisodeContext(ctx context.Context, name string,
+	c chan<- []Episode) error {
+	return send(ctx, s.EpisodePager(ctx, name, PageOpts{}), c, queryEpisode, name)
+}
+
+// EpisodePager returns Pager over episodes with requested name.
+func (s *Search) EpisodePager(ctx context.Context, name string,
+	o PageOpts) *Pager[Episode] {
+	return searchPager(ctx, s, queryEpisode, name, o, s.decodeEpisodes)
+}
+
+// decodeEpisodes decodes page of episodes found by search.
+func (s *Search) decodeEpisodes(_ context.Context,
+	b []byte) ([]Episode, respHeader, error) {
+	var resp episodeResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
 	}
-	v := reflect.ValueOf(resp).Elem()
-	if v.Kind() == reflect.Invalid || v.NumField() != 1 {
-		return errInvResp
+	return conv(&resp).([]Episode), resp.Episodes.respHeader, nil
+}
+
+// AudiobookContext searches for requested audiobooks and sends them through c
+// page by page. It stops when all pages were sent or ctx is done and closes c
+// before returning. Returned error is nil or *SearchError.
+func (s *Search) AudiobookContext(ctx context.Context, name string,
+	c chan<- []Audiobook) error {
+	return send(ctx, s.AudiobookPager(ctx, name, PageOpts{}), c, queryAudiobook,
+		name)
+}
+
+// AudiobookPager returns Pager over audiobooks with requested name.
+func (s *Search) AudiobookPager(ctx context.Context, name string,
+	o PageOpts) *Pager[Audiobook] {
+	return searchPager(ctx, s, queryAudiobook, name, o, s.decodeAudiobooks)
+}
+
+// decodeAudiobooks decodes page of audiobooks found by search.
+func (s *Search) decodeAudiobooks(_ context.Context,
+	b []byte) ([]Audiobook, respHeader, error) {
+	var resp audiobookResp
+	if err := unmarshal(b, &resp); err != nil {
+		return nil, respHeader{}, err
 	}
-	if v.Field(0).Kind() == reflect.Invalid ||
-		v.Field(0).FieldByName(next).Kind() == reflect.Invalid {
-		return errInvResp
+	return conv(&resp).([]Audiobook), resp.Audiobooks.respHeader, nil
+}
+
+// searchPager returns Pager over items of type t with name. The Pager fails
+// without sending any request if name is not a valid query.
+func searchPager[T any](ctx context.Context, s *Search, t, name string,
+	o PageOpts, d decoder[T]) *Pager[T] {
+	if err := validateQuery(name); err != nil {
+		return &Pager[T]{ctx: ctx, err: err}
 	}
-	if v.Field(0).FieldByName(next).IsNil() {
-		return errEOF
+	return newPager(ctx, s.get, s.url(t, name), s.opts(o), d)
+}
+
+// url returns URL of search for items of type t with name val.
+func (s *Search) url(t, val string) string {
+	return fmt.Sprintf(queryURL, url.QueryEscape(val), t)
+}
+
+// opts returns o with default page size set.
+func (s *Search) opts(o PageOpts) PageOpts {
+	if o.Limit == 0 {
+		o.Limit = int(s.batch)
+	}
+	return o
+}
+
+// send sends pages obtained from p through c until there are no more pages
+// or ctx is done. c is closed before returning.
+func send[T any](ctx context.Context, p *Pager[T], c chan<- []T, t,
+	val string) error {
+	defer close(c)
+	for p.Next() {
+		select {
+		case c <- p.Page():
+		case <-ctx.Done():
+			return &SearchError{t, val, uint(p.off - len(p.Page())), ctx.Err()}
+		}
+	}
+	if err := p.Err(); err != nil {
+		return &SearchError{t, val, uint(p.off), err}
 	}
 	return nil
 }
 
+// legacy runs search f forwarding its results to c and sending the final
+// error, or errEOF on success, through errch.
+func legacy[T any](f func(chan<- []T) error, c chan<- []T,
+	errch chan<- error) {
+	ch, done := make(chan []T), make(chan error, 1)
+	go func() {
+		done <- f(ch)
+	}()
+	for v := range ch {
+		c <- v
+	}
+	err := <-done
+	if err == nil {
+		err = errEOF
+	}
+	errch <- err
+}
+
+// strings used for interacting with Spotify Web API
+const (
+	endPointURL    = "https://api.spotify.com/v1/"
+	queryURL       = endPointURL + "search?q=%s&type=%s"
+	lookupURL      = endPointURL + "%s/%s"
+	multiLookupURL = endPointURL + "%s?ids=%s"
+	queryArtist    = "artist"
+	queryAlbum     = "album"
+	queryTrack     = "track"
+	queryPlaylist  = "playlist"
+	queryShow      = "show"
+	queryEpisode   = "episode"
+	queryAudiobook = "audiobook"
+	lookupAlbum    = "albums"
+	lookupArtist   = "artists"
+	lookupTrack    = "tracks"
+	lookupPlaylist = "playlists"
+)
+
 func unmarshal(body []byte, resp interface{}) error {
 	var e webError
 	if err := json.Unmarshal(body, &e); err == nil && e.Err.Status != 0 {
@@ -146,74 +353,77 @@ func unmarshal(body []byte, resp interface{}) error {
 	return nil
 }
 
+// albumsBatch is a maximum number of IDs accepted by multiple albums endpoint.
+const albumsBatch = 20
+
+// lookupWorkers is a maximum number of concurrent album lookups.
+const lookupWorkers = 4
+
 // lookupAlbums goes through all obtained albums by query of album
-// and fills in data structure with information about their artists.
-func (s *Search) lookupAlbums(d interface{}) (err error) {
-	r, body, resp := &http.Response{}, []byte(nil), albumArtist{}
-	res := d.([]Album)
-	for i := range res {
-		if r, err = s.get.get(fmt.Sprintf(lookupURL, lookupAlbum,
-			strings.TrimPrefix(res[i].URI, "spotify:album:"))); err != nil {
-			return
-		}
-		if body, err = ioutil.ReadAll(r.Body); err != nil {
-			r.Body.Close()
-			return
-		}
-		if err = json.Unmarshal(body, &resp); err != nil {
-			r.Body.Close()
-			return
-		}
-		for j := range resp.Artists {
-			res[i].Artists = append(res[i].Artists,
-				Artist{
-					URI:  resp.Artists[j].URI,
-					Name: resp.Artists[j].Name,
-				})
+// and fills in data structure with information about their artists, label,
+// popularity and genres. Albums are looked up in batches of albumsBatch,
+// at most lookupWorkers at once.
+func (s *Search) lookupAlbums(ctx context.Context, res []Album) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobs, errs := make(chan []Album), make(chan error, lookupWorkers)
+	var wg sync.WaitGroup
+	for i := 0; i < lookupWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range jobs {
+				if err := s.lookupBatch(ctx, b); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+LOOP:
+	for i := 0; i < len(res); i += albumsBatch {
+		select {
+		case jobs <- res[i:minInt(i+albumsBatch, len(res))]:
+		case <-ctx.Done():
+			break LOOP
 		}
-		r.Body.Close()
 	}
-	return
-}
-
-// sendRes sends partial results through channel.
-func sendRes(res, v interface{}) {
-	r := reflect.New(reflect.TypeOf(res).Elem())
-	r.Elem().Set(reflect.ValueOf(v))
-	reflect.ValueOf(res).Send(r.Elem())
-}
-
-const timeout = 30 * time.Second // timeout for HTTP requests.
-
-// geter is an interface for HTTP GET requests.
-type geter interface {
-	get(string) (*http.Response, error)
-}
-
-// get is a control structure implementing geter.
-type get struct {
-	c *http.Client
+	close(jobs)
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		return err
+	}
+	return ctx.Err()
 }
 
-// get implements geter.
-func (g get) get(url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+// lookupBatch fills in albums b with data obtained with single request
+// of multiple albums endpoint.
+func (s *Search) lookupBatch(ctx context.Context, b []Album) error {
+	ids := make([]string, len(b))
+	for i := range b {
+		ids[i] = URI(b[i].URI).ID()
+	}
+	r, err := s.get.get(ctx, fmt.Sprintf(multiLookupURL, lookupAlbum,
+		strings.Join(ids, ",")))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return g.c.Do(req)
-}
-
-// newGet returns a default implementation of geter.
-func newGet() geter {
-	return get{
-		&http.Client{
-			Transport: &http.Transport{
-				Dial: func(n, a string) (net.Conn, error) {
-					return net.DialTimeout(n, a, timeout)
-				},
-				TLSClientConfig: &tls.Config{},
-			},
-		},
+	defer r.Body.Close()
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	var resp albumsResp
+	if err = unmarshal(body, &resp); err != nil {
+		return err
 	}
+	for i := range resp.Albums {
+		if i >= len(b) || resp.Albums[i] == nil {
+			continue
+		}
+		resp.Albums[i].fill(&b[i])
+	}
+	return nil
 }
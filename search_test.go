@@ -1,8 +1,13 @@
 package spotify
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -77,13 +82,9 @@ func TestAlbum(t *testing.T) {
 		get: &getMock{
 			d: []string{
 				jsonData(t, "album_1.json"),
-				jsonData(t, "album_1_artist_1.json"),
-				jsonData(t, "album_1_artist_2.json"),
-				jsonData(t, "album_1_artist_1.json"),
-				jsonData(t, "album_1_artist_2.json"),
-				jsonData(t, "album_1_artist_1.json"),
+				jsonData(t, "albums_1.json"),
 				jsonData(t, "album_2.json"),
-				jsonData(t, "album_1_artist_2.json"),
+				jsonData(t, "albums_2.json"),
 			},
 		},
 		batch: 5,
@@ -171,3 +172,250 @@ func TestArtistError(t *testing.T) {
 		return
 	}
 }
+
+func TestArtistContext(t *testing.T) {
+	t.Parallel()
+	s := &Search{
+		get: &getMock{
+			d: []string{
+				jsonData(t, "artist_1.json"),
+				jsonData(t, "artist_2.json"),
+			},
+		},
+		batch: 5,
+	}
+	ch := make(chan []Artist)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.ArtistContext(context.Background(), "", ch)
+	}()
+	i := 0
+	for c := range ch {
+		if !reflect.DeepEqual(c, searchArtistFixt.res[i]) {
+			t.Errorf("want c=searchArtistFixt.res[i]; got %v==%v (%d)",
+				c, searchArtistFixt.res[i], i)
+		}
+		i++
+	}
+	if l := len(searchArtistFixt.res) - 1; i != l {
+		t.Errorf("want i=l; got %d=%d", i, l)
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("want err=nil; got %q", err)
+	}
+}
+
+func TestArtistContextCancel(t *testing.T) {
+	t.Parallel()
+	s := &Search{
+		get: &getMock{
+			d: []string{
+				jsonData(t, "artist_1.json"),
+				jsonData(t, "artist_2.json"),
+			},
+		},
+		batch: 5,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan []Artist)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- s.ArtistContext(ctx, "", ch)
+	}()
+	<-ch
+	cancel()
+	select {
+	case err := <-errc:
+		var serr *SearchError
+		if !errors.As(err, &serr) {
+			t.Fatalf("want err=*SearchError; got %v", err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("want err=context.Canceled; got %v", err)
+		}
+		if serr.Type != queryArtist || serr.Offset != 5 {
+			t.Errorf("want {artist 5}; got {%s %d}", serr.Type, serr.Offset)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+	if _, ok := <-ch; ok {
+		t.Error("want ch closed")
+	}
+}
+
+func TestLookupAlbumsBatches(t *testing.T) {
+	t.Parallel()
+	var res []Album
+	for i := 0; i < 45; i++ {
+		res = append(res, Album{URI: fmt.Sprintf("spotify:album:%d", i)})
+	}
+	g := &getMock{d: []string{`{"albums":[]}`, `{"albums":[]}`,
+		`{"albums":[]}`}}
+	s := &Search{get: g}
+	if err := s.lookupAlbums(context.Background(), res); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if len(g.u) != 3 {
+		t.Fatalf("want 3 requests; got %d", len(g.u))
+	}
+	sort.Strings(g.u)
+	for i, n := range []int{20, 20, 5} {
+		u, err := url.Parse(g.u[i])
+		if err != nil {
+			t.Fatalf("want err=nil; got %q", err)
+		}
+		if ids := strings.Split(u.Query().Get("ids"), ","); len(ids) != n {
+			t.Errorf("want len(ids)=%d; got %d (%q)", n, len(ids), g.u[i])
+		}
+	}
+}
+
+func TestSearchTypes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	cases := []struct {
+		file string
+		res  func(*Search) (interface{}, error)
+		want interface{}
+	}{
+		{
+			file: "playlist_1.json",
+			res: func(s *Search) (interface{}, error) {
+				return s.PlaylistPager(ctx, "", PageOpts{}).All()
+			},
+			want: []Playlist{
+				{
+					URI:  "spotify:playlist:37i9dQZF1DZ06evO1tcN2z",
+					Name: "This Is Tenacious D", Description: "The best of Tenacious D.",
+					Owner: User{ID: "spotify", URI: "spotify:user:spotify",
+						Name: "Spotify"},
+					Public: true, Tracks: 46,
+				},
+				{
+					URI:  "spotify:playlist:0vvXsWCC9xrXsKd4FyS8kM",
+					Name: "Tenacious D - Rize of the Fenix",
+					Owner: User{ID: "kg1999", URI: "spotify:user:kg1999",
+						Name: "Kyle"},
+					Tracks: 13,
+				},
+			},
+		},
+		{
+			file: "show_1.json",
+			res: func(s *Search) (interface{}, error) {
+				return s.ShowPager(ctx, "", PageOpts{}).All()
+			},
+			want: []Show{
+				{
+					URI: "spotify:show:5CfCWKI5pZ28U0uOzXkDHe", Name: "Tenacious Talk",
+					Publisher:   "Tenacious D",
+					Description: "Jack Black and Kyle Gass talk about everything.",
+					Explicit:    true, Episodes: 42,
+				},
+			},
+		},
+		{
+			file: "episode_1.json",
+			res: func(s *Search) (interface{}, error) {
+				return s.EpisodePager(ctx, "", PageOpts{}).All()
+			},
+			want: []Episode{
+				{
+					URI:  "spotify:episode:512ojhOuo1ktJprKbVcKyQ",
+					Name: "Episode 1: The Road", Description: "The D on tour.",
+					Duration: 25*time.Minute + 2*time.Second, ReleaseDate: "2019-11-05",
+				},
+			},
+		},
+		{
+			file: "audiobook_1.json",
+			res: func(s *Search) (interface{}, error) {
+				return s.AudiobookPager(ctx, "", PageOpts{}).All()
+			},
+			want: []Audiobook{
+				{
+					URI:  "spotify:audiobook:7iHfbu1YPACw6oZPAFJtqe",
+					Name: "The Pick of Destiny", Authors: []string{"Jack Black", "Kyle Gass"},
+					Narrators: []string{"Jack Black"}, Publisher: "Tenacious Press",
+					Chapters: 12,
+				},
+			},
+		},
+	}
+	for i, cas := range cases {
+		s := &Search{get: &getMock{d: []string{jsonData(t, cas.file)}}, batch: 5}
+		res, err := cas.res(s)
+		if err != nil {
+			t.Errorf("want err=nil; got %q (%d)", err, i)
+			continue
+		}
+		if !reflect.DeepEqual(res, cas.want) {
+			t.Errorf("want res=cas.want; got %+v=%+v (%d)", res, cas.want, i)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+	var a, tr map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(jsonData(t, "artist_1.json")), &a); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if err := json.Unmarshal([]byte(jsonData(t, "track_2.json")), &tr); err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	a["tracks"] = tr["tracks"]
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	g := &getMock{d: []string{string(b), jsonData(t, "artist_2.json")}}
+	s := &Search{get: g, batch: 5}
+	res, err := s.All(context.Background(), "Tenacious",
+		SearchArtist|SearchTrack, PageOpts{})
+	if err != nil {
+		t.Fatalf("want err=nil; got %q", err)
+	}
+	if res.Albums != nil || res.Artists == nil || res.Tracks == nil {
+		t.Fatalf("want only artist and track pagers; got %+v", res)
+	}
+	if !strings.Contains(g.u[0], "type=artist,track&limit=5") {
+		t.Errorf("want type=artist,track&limit=5 in %q", g.u[0])
+	}
+	if !res.Tracks.Next() ||
+		!reflect.DeepEqual(res.Tracks.Page(), searchTrackFixt.res[1]) {
+		t.Errorf("want tracks=%v; got %v", searchTrackFixt.res[1],
+			res.Tracks.Page())
+	}
+	for i := 0; res.Artists.Next(); i++ {
+		if !reflect.DeepEqual(res.Artists.Page(), searchArtistFixt.res[i]) {
+			t.Errorf("want artists=%v; got %v (%d)", searchArtistFixt.res[i],
+				res.Artists.Page(), i)
+		}
+	}
+	if len(g.u) != 2 {
+		t.Errorf("want 2 requests; got %d", len(g.u))
+	}
+	if _, err = s.All(context.Background(), "", 0, PageOpts{}); err == nil {
+		t.Error("want err!=nil for empty type set")
+	}
+}
+
+func TestSearchTypeString(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		t   SearchType
+		res string
+	}{
+		{SearchArtist, "artist"},
+		{SearchAlbum | SearchEpisode, "album,episode"},
+		{SearchAllTypes, "artist,album,track,playlist,show,episode,audiobook"},
+		{0, ""},
+	}
+	for i, cas := range cases {
+		if res := cas.t.String(); res != cas.res {
+			t.Errorf("want res=cas.res; got %q=%q (%d)", res, cas.res, i)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package spotify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TokenStore persists tokens of logged in users on disk. Each profile is
+// stored in a separate file readable only by its owner.
+type TokenStore struct {
+	Dir string // Dir is a directory containing stored tokens.
+}
+
+// NewTokenStore returns TokenStore keeping tokens in dir. If dir is empty,
+// go.spotify directory in user's configuration directory is used.
+func NewTokenStore(dir string) (*TokenStore, error) {
+	if dir == "" {
+		cfg, err := os.UserConfigDir()
+		if err != nil {
+			return nil, errorf("failed to find config dir: %q", err)
+		}
+		dir = filepath.Join(cfg, "go.spotify")
+	}
+	return &TokenStore{Dir: dir}, nil
+}
+
+// ErrNoToken is returned if there is no token stored for a profile.
+var ErrNoToken = errorf("no token stored for the profile")
+
+// IsNoToken returns a boolean indicating whether the error is known to report
+// that there is no token stored for a profile.
+func IsNoToken(err error) bool {
+	return err == ErrNoToken
+}
+
+// Load returns token stored for profile.
+func (s *TokenStore) Load(profile string) (*Token, error) {
+	name, err := s.path(profile)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, errorf("failed to read token: %q", err)
+	}
+	var tok Token
+	if err = json.Unmarshal(b, &tok); err != nil {
+		return nil, errorf("failed to decode token %q: %q", name, err)
+	}
+	return &tok, nil
+}
+
+// Save stores tok for profile. Stored file has 0600 permissions.
+func (s *TokenStore) Save(profile string, tok *Token) error {
+	name, err := s.path(profile)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(tok, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(s.Dir, 0700); err != nil {
+		return errorf("failed to create token dir: %q", err)
+	}
+	f, err := ioutil.TempFile(s.Dir, "."+profile)
+	if err != nil {
+		return errorf("failed to save token: %q", err)
+	}
+	defer os.Remove(f.Name())
+	if err = f.Chmod(0600); err == nil {
+		_, err = f.Write(b)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), name)
+	}
+	if err != nil {
+		return errorf("failed to save token: %q", err)
+	}
+	return nil
+}
+
+// Delete removes token stored for profile. It returns ErrNoToken if there is
+// no such token.
+func (s *TokenStore) Delete(profile string) error {
+	name, err := s.path(profile)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(name); os.IsNotExist(err) {
+		return ErrNoToken
+	}
+	return err
+}
+
+// path returns name of the file storing token of profile.
+func (s *TokenStore) path(profile string) (string, error) {
+	if profile == "" || strings.ContainsAny(profile, `/\:`) ||
+		strings.HasPrefix(profile, ".") {
+		return "", errorf("invalid profile name: %q", profile)
+	}
+	return filepath.Join(s.Dir, profile+".json"), nil
+}
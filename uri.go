@@ -0,0 +1,182 @@
+package spotify
+
+import (
+	"encoding/hex"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+// URI is a type representing Spotify URI in its canonical form
+// spotify:<kind>:<id>, e.g. spotify:track:6crBy2sODw2HS53xquM6us. Local files
+// are represented by spotify:local:<artist>:<album>:<title>:<seconds>.
+type URI string
+
+// Kinds of Spotify URIs.
+const (
+	KindTrack     = "track"
+	KindAlbum     = "album"
+	KindArtist    = "artist"
+	KindPlaylist  = "playlist"
+	KindShow      = "show"
+	KindEpisode   = "episode"
+	KindAudiobook = "audiobook"
+	KindUser      = "user"
+	KindLocal     = "local"
+)
+
+const (
+	uriScheme = "spotify"
+	openHost  = "open.spotify.com"
+	openURL   = "https://" + openHost + "/"
+	base62    = "0123456789abcdefghijklmnopqrstuvwxyz" +
+		"ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	idLen  = 22 // idLen is a length of base62 encoded ID.
+	gidLen = 32 // gidLen is a length of hex encoded GID.
+)
+
+// ParseURI parses Spotify URI or open.spotify.com URL and returns URI in its
+// canonical form. Accepted forms are:
+//   - spotify:<kind>:<id>
+//   - spotify:user:<user>:playlist:<id>
+//   - spotify:local:<artist>:<album>:<title>:<seconds>
+//   - https://open.spotify.com/[intl-<lang>/]<kind>/<id>[?<query>]
+//   - https://open.spotify.com/user/<user>/playlist/<id>[?<query>]
+//
+// Scheme of open.spotify.com URL can be omitted.
+func ParseURI(s string) (URI, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, uriScheme+":") {
+		return parseURI(s)
+	}
+	return parseURL(s)
+}
+
+// parseURI parses URI with spotify scheme.
+func parseURI(s string) (URI, error) {
+	p := strings.Split(s, ":")
+	switch {
+	case len(p) >= 3 && p[1] == KindLocal:
+		return URI(s), nil
+	case len(p) == 5 && p[1] == KindUser && p[3] == KindPlaylist:
+		return makeURI(KindPlaylist, p[4])
+	case len(p) == 3:
+		return makeURI(p[1], p[2])
+	}
+	return "", errorf("invalid URI: %q", s)
+}
+
+// parseURL parses open.spotify.com URL.
+func parseURL(s string) (URI, error) {
+	if strings.HasPrefix(s, openHost+"/") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Host != openHost {
+		return "", errorf("invalid URL: %q", s)
+	}
+	p := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(p) > 0 && strings.HasPrefix(p[0], "intl-") {
+		p = p[1:]
+	}
+	switch {
+	case len(p) == 4 && p[0] == KindUser && p[2] == KindPlaylist:
+		return makeURI(KindPlaylist, p[3])
+	case len(p) == 2:
+		return makeURI(p[0], p[1])
+	}
+	return "", errorf("invalid URL: %q", s)
+}
+
+// makeURI returns URI of kind and id, validating both.
+func makeURI(kind, id string) (URI, error) {
+	switch kind {
+	case KindTrack, KindAlbum, KindArtist, KindPlaylist, KindShow,
+		KindEpisode, KindAudiobook:
+		if !isBase62(id) {
+			return "", errorf("invalid %s ID: %q", kind, id)
+		}
+	case KindUser:
+		if id == "" {
+			return "", errorf("empty user ID")
+		}
+	default:
+		return "", errorf("unsupported kind: %q", kind)
+	}
+	return URI(uriScheme + ":" + kind + ":" + id), nil
+}
+
+// isBase62 reports whether id is a valid base62 encoded ID.
+func isBase62(id string) bool {
+	if len(id) != idLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if strings.IndexByte(base62, id[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Kind returns kind of u, e.g. track, or empty string if u is malformed.
+func (u URI) Kind() string {
+	p := strings.SplitN(string(u), ":", 3)
+	if len(p) < 3 || p[0] != uriScheme {
+		return ""
+	}
+	return p[1]
+}
+
+// ID returns ID of u, or empty string if u is malformed. For local files
+// it returns the part following spotify:local:.
+func (u URI) ID() string {
+	p := strings.SplitN(string(u), ":", 3)
+	if len(p) < 3 || p[0] != uriScheme {
+		return ""
+	}
+	return p[2]
+}
+
+// URL returns open.spotify.com URL of u. Local files have no URL, so empty
+// string is returned for them.
+func (u URI) URL() string {
+	if k := u.Kind(); k != "" && k != KindLocal {
+		return openURL + k + "/" + u.ID()
+	}
+	return ""
+}
+
+// GID returns ID of u as hex encoded 128-bit number, as used internally by
+// Spotify.
+func (u URI) GID() (string, error) {
+	id := u.ID()
+	if !isBase62(id) {
+		return "", errorf("URI has no base62 ID: %q", u)
+	}
+	n, b := new(big.Int), big.NewInt(62)
+	for i := 0; i < len(id); i++ {
+		n.Mul(n, b)
+		n.Add(n, big.NewInt(int64(strings.IndexByte(base62, id[i]))))
+	}
+	if n.BitLen() > 128 {
+		return "", errorf("ID out of range: %q", id)
+	}
+	g := make([]byte, gidLen/2)
+	return hex.EncodeToString(n.FillBytes(g)), nil
+}
+
+// URIFromGID returns URI of kind for hex encoded GID.
+func URIFromGID(kind, gid string) (URI, error) {
+	g, err := hex.DecodeString(gid)
+	if err != nil || len(g) != gidLen/2 {
+		return "", errorf("invalid GID: %q", gid)
+	}
+	n, b, m := new(big.Int).SetBytes(g), big.NewInt(62), new(big.Int)
+	id := make([]byte, idLen)
+	for i := idLen - 1; i >= 0; i-- {
+		n.DivMod(n, b, m)
+		id[i] = base62[m.Int64()]
+	}
+	return makeURI(kind, string(id))
+}
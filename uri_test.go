@@ -0,0 +1,126 @@
+package spotify
+
+import "testing"
+
+func TestParseURI(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		s     string
+		res   URI
+		isnil bool
+	}{
+		{
+			s:     "spotify:track:6crBy2sODw2HS53xquM6us",
+			res:   "spotify:track:6crBy2sODw2HS53xquM6us",
+			isnil: true,
+		},
+		{
+			s:     "https://open.spotify.com/album/1AckkxSo39144vOBrJ1GkS?si=abc",
+			res:   "spotify:album:1AckkxSo39144vOBrJ1GkS",
+			isnil: true,
+		},
+		{
+			s:     "https://open.spotify.com/intl-de/artist/1XpDYCrUJnvCo9Ez6yeMWh",
+			res:   "spotify:artist:1XpDYCrUJnvCo9Ez6yeMWh",
+			isnil: true,
+		},
+		{
+			s:     "open.spotify.com/track/6crBy2sODw2HS53xquM6us?si=abc",
+			res:   "spotify:track:6crBy2sODw2HS53xquM6us",
+			isnil: true,
+		},
+		{
+			s:     "spotify:user:tenaciousd:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			res:   "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			isnil: true,
+		},
+		{
+			s:     "https://open.spotify.com/user/tenaciousd/playlist/37i9dQZF1DXcBWIGoYBM5M",
+			res:   "spotify:playlist:37i9dQZF1DXcBWIGoYBM5M",
+			isnil: true,
+		},
+		{
+			s:     "spotify:local:Tenacious+D:Tenacious+D:Tribute:248",
+			res:   "spotify:local:Tenacious+D:Tenacious+D:Tribute:248",
+			isnil: true,
+		},
+		{
+			s:     "spotify:user:tenaciousd",
+			res:   "spotify:user:tenaciousd",
+			isnil: true,
+		},
+		{s: "spotify:track:6crBy2sODw2HS53xquM6u"},
+		{s: "spotify:track:6crBy2sODw2HS53xquM6u!"},
+		{s: "spotify:genre:6crBy2sODw2HS53xquM6us"},
+		{s: "https://example.com/track/6crBy2sODw2HS53xquM6us"},
+		{s: "https://open.spotify.com/track"},
+		{s: "open.spotify.com"},
+		{s: "tribute"},
+	}
+	for i, cas := range cases {
+		res, err := ParseURI(cas.s)
+		if (err == nil) != cas.isnil {
+			t.Errorf("want isnil=%t; got err=%v (%d)", cas.isnil, err, i)
+		}
+		if res != cas.res {
+			t.Errorf("want res=%q; got %q (%d)", cas.res, res, i)
+		}
+	}
+}
+
+func TestURIParts(t *testing.T) {
+	t.Parallel()
+	u := URI("spotify:track:6crBy2sODw2HS53xquM6us")
+	if k := u.Kind(); k != KindTrack {
+		t.Errorf("want kind=%q; got %q", KindTrack, k)
+	}
+	if id := u.ID(); id != "6crBy2sODw2HS53xquM6us" {
+		t.Errorf("want id=%q; got %q", "6crBy2sODw2HS53xquM6us", id)
+	}
+	want := "https://open.spotify.com/track/6crBy2sODw2HS53xquM6us"
+	if url := u.URL(); url != want {
+		t.Errorf("want url=%q; got %q", want, url)
+	}
+	if url := URI("spotify:local:a:b:c:1").URL(); url != "" {
+		t.Errorf("want url=\"\"; got %q", url)
+	}
+	if k := URI("tribute").Kind(); k != "" {
+		t.Errorf("want kind=\"\"; got %q", k)
+	}
+}
+
+func TestGID(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		uri URI
+		gid string
+	}{
+		{"spotify:track:0000000000000000000000", "00000000000000000000000000000000"},
+		{"spotify:track:0000000000000000000001", "00000000000000000000000000000001"},
+		{"spotify:track:000000000000000000000Z", "0000000000000000000000000000003d"},
+		{"spotify:track:7N42dgm5tFLK9N8MT7fHC6", "fffffffffffffffffffffffffffffffe"},
+		{"spotify:track:6crBy2sODw2HS53xquM6us", ""},
+	}
+	for i, cas := range cases {
+		gid, err := cas.uri.GID()
+		if err != nil {
+			t.Fatalf("want err=nil; got %q (%d)", err, i)
+		}
+		if cas.gid != "" && gid != cas.gid {
+			t.Errorf("want gid=%q; got %q (%d)", cas.gid, gid, i)
+		}
+		uri, err := URIFromGID(KindTrack, gid)
+		if err != nil {
+			t.Fatalf("want err=nil; got %q (%d)", err, i)
+		}
+		if uri != cas.uri {
+			t.Errorf("want uri=%q; got %q (%d)", cas.uri, uri, i)
+		}
+	}
+	if _, err := URI("spotify:track:zzzzzzzzzzzzzzzzzzzzzz").GID(); err == nil {
+		t.Error("want err!=nil for ID out of range")
+	}
+	if _, err := URIFromGID(KindTrack, "abc"); err == nil {
+		t.Error("want err!=nil for invalid GID")
+	}
+}
@@ -19,6 +19,14 @@ func min(x, y int32) int32 {
 	return y
 }
 
+// minInt returns the smaller of x or y.
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
+
 func errorf(format string, args ...interface{}) error {
 	return fmt.Errorf("[spotify]: "+format, args...)
 }